@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bufio"
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/infrastructure/database"
+	"cleanArch_with_postgres/internal/infrastructure/eventbus"
+	"cleanArch_with_postgres/internal/infrastructure/logger"
+	"cleanArch_with_postgres/internal/infrastructure/storage"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// adminEnv, yönetim komutlarının kullandığı servisler; sunucuyla aynı config, repository ve
+// servisler üzerine kurulur, webhook ve bildirimler de sunucudaki gibi üretilir
+type adminEnv struct {
+	ur    repository.UserRepository
+	auth  service.AuthService
+	blog  service.BlogService
+	bus   eventbus.Bus
+	sqlDB *sql.DB
+}
+
+// newAdminEnv, hata dönerse o ana kadar açtığı bağlantıları kapatır; başarılıysa kapatmak close'un işi
+func newAdminEnv() (*adminEnv, error) {
+	cfg, err := config.Setup()
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	if _, err := logger.Setup(cfg.Log); err != nil {
+		return nil, fmt.Errorf("log: %w", err)
+	}
+	db, sqlDB, err := openDB(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("database: %w", err)
+	}
+	bus, err := eventbus.New(cfg.Events, db, database.DSN(cfg.Database))
+	if err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("eventbus: %w", err)
+	}
+	store, err := storage.New(cfg.Media)
+	if err != nil {
+		bus.Close()
+		sqlDB.Close()
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+
+	ur := repository.NewUserRepository(db)
+	br := repository.NewBlogRepository(db)
+	ns := service.NewNotificationService(repository.NewNotificationRepository(db), ur, bus)
+	ws := service.NewWebhookService(repository.NewWebhookRepository(db), cfg.Webhook)
+	ms := service.NewMediaService(repository.NewMediaRepository(db), ur, store, cfg.Media)
+	return &adminEnv{
+		ur:    ur,
+		auth:  service.NewAuthService(ur, br, repository.NewRoleRequestRepository(db), ns, ws),
+		blog:  service.NewBlogService(br, ur, ns, ws, ms),
+		bus:   bus,
+		sqlDB: sqlDB,
+	}, nil
+}
+
+// close, event bus'ı DB'den önce kapatır; bus aynı bağlantıyı kullanıyor olabilir
+func (e *adminEnv) close() {
+	if err := e.bus.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, "eventbus close:", err)
+	}
+	if err := e.sqlDB.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, "database close:", err)
+	}
+}
+
+// requireAdmin, -as ile verilen kullanıcının admin olduğunu doğrular; karar kayıtlarına bu isim yazılır
+func (e *adminEnv) requireAdmin(ctx context.Context, username string) error {
+	if username == "" {
+		return errors.New("-as <admin username> is required")
+	}
+	u, err := e.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("user %q not found", username)
+	}
+	if u.Role != entity.RoleAdmin || u.DeletedAt.Valid {
+		return fmt.Errorf("user %q is not an active admin", username)
+	}
+	return nil
+}
+
+// adminCommand, ortak kurulumu yapar ve fn'i çalıştırır; hata fn'den dönerse çıkış kodu 1
+func adminCommand(name string, fn func(ctx context.Context, e *adminEnv) error) int {
+	e, err := newAdminEnv()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer e.close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := fn(ctx, e); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 1
+	}
+	return 0
+}
+
+func userCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	fs := flag.NewFlagSet("user "+args[0], flag.ContinueOnError)
+	switch args[0] {
+	case "create":
+		username := fs.String("username", "", "")
+		email := fs.String("email", "", "")
+		role := fs.String("role", "reader", "reader, writer veya admin")
+		locale := fs.String("locale", "", "")
+		if fs.Parse(args[1:]) != nil || fs.NArg() != 0 {
+			return 2
+		}
+		return adminCommand("user create", func(ctx context.Context, e *adminEnv) error {
+			password, err := readPassword()
+			if err != nil {
+				return err
+			}
+			resp, err := e.auth.CreateUser(ctx, viewmodel.RegisterRequest{
+				Username: *username, Email: *email, Password: password, Role: *role, Locale: *locale,
+			})
+			if err != nil {
+				return err
+			}
+			return printJSON(resp)
+		})
+	case "reset-password":
+		mustReset := fs.Bool("must-reset", false, "kullanıcı ilk girişte şifresini değiştirmeli")
+		if fs.Parse(args[1:]) != nil || fs.NArg() != 1 {
+			return 2
+		}
+		return adminCommand("user reset-password", func(ctx context.Context, e *adminEnv) error {
+			password, err := readPassword()
+			if err != nil {
+				return err
+			}
+			if err := e.auth.ResetPassword(ctx, fs.Arg(0), password, *mustReset); err != nil {
+				return err
+			}
+			fmt.Println("password updated:", fs.Arg(0))
+			return nil
+		})
+	case "restore":
+		if fs.Parse(args[1:]) != nil || fs.NArg() != 1 {
+			return 2
+		}
+		return adminCommand("user restore", func(ctx context.Context, e *adminEnv) error {
+			if err := e.auth.RestoreUser(ctx, fs.Arg(0)); err != nil {
+				return err
+			}
+			fmt.Println("user restored:", fs.Arg(0))
+			return nil
+		})
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+}
+
+func roleRequestsCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	fs := flag.NewFlagSet("role-requests "+args[0], flag.ContinueOnError)
+	switch args[0] {
+	case "list":
+		status := fs.String("status", "pending", "pending, approved, rejected veya all")
+		limit := fs.Int("limit", 50, "")
+		if fs.Parse(args[1:]) != nil || fs.NArg() != 0 {
+			return 2
+		}
+		return adminCommand("role-requests list", func(ctx context.Context, e *adminEnv) error {
+			rows, err := e.auth.ListRoleRequests(ctx, *status, *limit)
+			if err != nil {
+				return err
+			}
+			return printJSON(rows)
+		})
+	case "approve", "reject":
+		as := fs.String("as", "", "kararı veren admin")
+		if fs.Parse(args[1:]) != nil || fs.NArg() != 1 {
+			return 2
+		}
+		id, err := strconv.ParseUint(fs.Arg(0), 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid role request id %q\n", fs.Arg(0))
+			return 2
+		}
+		decide, result := "reject", "rejected"
+		if args[0] == "approve" {
+			decide, result = "approve", "approved"
+		}
+		return adminCommand("role-requests "+decide, func(ctx context.Context, e *adminEnv) error {
+			if err := e.requireAdmin(ctx, *as); err != nil {
+				return err
+			}
+			fn := e.auth.RejectRoleRequest
+			if decide == "approve" {
+				fn = e.auth.ApproveRoleRequest
+			}
+			if err := fn(ctx, uint(id), *as); err != nil {
+				return err
+			}
+			fmt.Printf("role request %d %s\n", id, result)
+			return nil
+		})
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+}
+
+func blogCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	fs := flag.NewFlagSet("blog "+args[0], flag.ContinueOnError)
+	as := fs.String("as", "", "işlemi yapan admin")
+	switch args[0] {
+	case "approve", "unapprove", "restore":
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	if fs.Parse(args[1:]) != nil || fs.NArg() != 1 {
+		return 2
+	}
+	title := fs.Arg(0)
+	return adminCommand("blog "+args[0], func(ctx context.Context, e *adminEnv) error {
+		if err := e.requireAdmin(ctx, *as); err != nil {
+			return err
+		}
+		var err error
+		switch args[0] {
+		case "approve":
+			err = e.blog.ApproveBlog(ctx, title, *as, true)
+		case "unapprove":
+			err = e.blog.ApproveBlog(ctx, title, *as, false)
+		case "restore":
+			err = e.blog.RestoreBlog(ctx, title, *as)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("blog %sd: %s\n", args[0], title)
+		return nil
+	})
+}
+
+// readPassword, şifreyi stdin'den tek satır olarak okur; argüman olarak alınmaz ki shell geçmişine
+// ve ps çıktısına düşmesin (örn. `printf '%s\n' "$PW" | cleanarch_with_postgres user create ...`).
+// stdin terminalse yazılanlar ekrana basılmaz.
+func readPassword() (string, error) {
+	var line string
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "password: ")
+		b, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		line = string(b)
+	} else {
+		var err error
+		line, err = bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("empty password on stdin")
+	}
+	return password, nil
+}
+
+func printJSON(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/app"
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/infrastructure/database"
+	"cleanArch_with_postgres/internal/infrastructure/eventbus"
+	"cleanArch_with_postgres/internal/infrastructure/logger"
+	"cleanArch_with_postgres/internal/infrastructure/openapi"
+	"cleanArch_with_postgres/internal/infrastructure/router"
+	"cleanArch_with_postgres/internal/infrastructure/storage"
+	"cleanArch_with_postgres/internal/infrastructure/worker"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/service"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const usage = `usage: cleanarch_with_postgres [command]
+
+Komut verilmezse API sunucusu başlar.
+
+commands:
+  import-wxr <file.xml>   WordPress WXR export'unu içe aktarır (tekrar çalıştırılabilir)
+  openapi                 OpenAPI dokümanını stdout'a yazar
+  openapi check           kayıtlı her route dokümanda mı kontrol eder; eksik varsa çıkış kodu 1
+  migrate status          migration'ları ve uygulanıp uygulanmadıklarını listeler
+  migrate up              bekleyen tüm migration'ları uygular
+  migrate down [-force]   son uygulanan migration'ı geri alır
+  migrate to [-force] <version>
+                          şemayı verilen versiyona (ileri ya da geri) getirir; 0 tüm şemayı siler.
+                          Tüm tabloları silecek bir down/to, -force verilmeden çalışmaz.
+
+yönetim komutları (şifreler stdin'den tek satır okunur):
+  user create -username <u> -email <e> [-role reader|writer|admin] [-locale tr|en]
+  user reset-password [-must-reset] <username>
+  user restore <username>
+  role-requests list [-status pending|approved|rejected|all] [-limit 50]
+  role-requests approve|reject -as <admin> <id>
+  blog approve|unapprove|restore -as <admin> <title>
+`
+
+func runCommand(args []string) int {
+	switch args[0] {
+	case "import-wxr":
+		return importWXR(args[1:])
+	case "openapi":
+		return openAPI(args[1:])
+	case "migrate":
+		return migrate(args[1:])
+	case "user":
+		return userCommand(args[1:])
+	case "role-requests":
+		return roleRequestsCommand(args[1:])
+	case "blog":
+		return blogCommand(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+func importWXR(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "open:", err)
+		return 1
+	}
+	defer f.Close()
+
+	cfg, err := config.Setup()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config:", err)
+		return 1
+	}
+	if _, err := logger.Setup(cfg.Log); err != nil {
+		fmt.Fprintln(os.Stderr, "log:", err)
+		return 1
+	}
+	db, sqlDB, err := openDB(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "database:", err)
+		return 1
+	}
+	defer sqlDB.Close()
+
+	is := service.NewImportService(
+		repository.NewImportRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewBlogRepository(db),
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := is.ImportWXR(ctx, f)
+	out, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(out))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "import-wxr:", err)
+		return 1
+	}
+	return 0
+}
+
+// openDB, komutlar için bağlantı açar; şema geride ise sunucudaki gibi database.automigrate'e göre
+// ya migration'ları uygular ya da hata döner. Dönen *sql.DB'yi kapatmak çağıranın işi.
+func openDB(cfg *config.Config) (*gorm.DB, *sql.DB, error) {
+	db, err := database.Open(cfg.Database, cfg.Log)
+	if err != nil {
+		return nil, nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := database.NewMigrator(db)
+	if err != nil {
+		sqlDB.Close()
+		return nil, nil, err
+	}
+	if err := database.EnsureSchema(context.Background(), m, cfg.Database.AutoMigrate); err != nil {
+		sqlDB.Close()
+		return nil, nil, err
+	}
+	return db, sqlDB, nil
+}
+
+func migrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	fs := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	force := fs.Bool("force", false, "tüm şemayı silen down/to'ya izin verir")
+	if fs.Parse(args[1:]) != nil || (args[0] == "to") != (fs.NArg() == 1) || fs.NArg() > 1 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	cfg, err := config.Setup()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config:", err)
+		return 1
+	}
+	if _, err := logger.Setup(cfg.Log); err != nil {
+		fmt.Fprintln(os.Stderr, "log:", err)
+		return 1
+	}
+	db, err := database.Open(cfg.Database, cfg.Log)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "database:", err)
+		return 1
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "database:", err)
+		return 1
+	}
+	defer sqlDB.Close()
+
+	m, err := database.NewMigrator(db)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var done []database.Migration
+	switch args[0] {
+	case "status":
+		return migrateStatus(ctx, m)
+	case "up":
+		done, err = m.Up(ctx)
+	case "down", "to":
+		current, curErr := m.Current(ctx)
+		if curErr != nil {
+			fmt.Fprintln(os.Stderr, "migrate:", curErr)
+			return 1
+		}
+		target := m.Previous(current)
+		if args[0] == "to" {
+			version, convErr := strconv.Atoi(fs.Arg(0))
+			if convErr != nil || version < 0 {
+				fmt.Fprintf(os.Stderr, "invalid version %q\n", fs.Arg(0))
+				return 2
+			}
+			target = version
+		}
+		// tüm tabloları (ve verileri) silecek adım açıkça istenmeli
+		if target == 0 && current > 0 && !*force {
+			fmt.Fprintf(os.Stderr, "migrate: going from version %d to 0 drops the whole schema and all data; re-run with -force\n", current)
+			return 1
+		}
+		if current == 0 && args[0] == "down" {
+			break
+		}
+		done, err = m.To(ctx, target)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	for _, mig := range done {
+		fmt.Printf("%04d_%s\n", mig.Version, mig.Name)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		return 1
+	}
+	current, err := m.Current(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		return 1
+	}
+	fmt.Printf("schema version: %d (latest %d)\n", current, m.Latest())
+	return 0
+}
+
+func migrateStatus(ctx context.Context, m *database.Migrator) int {
+	status, err := m.Status(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		return 1
+	}
+	for _, s := range status {
+		state := "pending"
+		if s.AppliedAt != nil {
+			state = "applied " + s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%04d  %-30s %s\n", s.Version, s.Name, state)
+	}
+	return 0
+}
+
+func openAPI(args []string) int {
+	if len(args) > 1 || (len(args) == 1 && args[0] != "check") {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+	cfg, err := config.Setup()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config:", err)
+		return 1
+	}
+	if len(args) == 1 {
+		return openAPICheck(cfg)
+	}
+
+	out, err := openapi.JSON(cfg.Site.Title+" API", "v1", openapi.Operations)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "openapi:", err)
+		return 1
+	}
+	fmt.Println(string(out))
+	return 0
+}
+
+// openAPICheck, router'ı DB'siz kurup kayıtlı route'ları dokümanla karşılaştırır; handler'lar çalışmaz.
+// Local storage seçilir ki /media/files route'u da kontrol edilsin.
+func openAPICheck(cfg *config.Config) int {
+	cfg.Media.Driver = "local"
+	cfg.Media.LocalDir = os.TempDir()
+	store, err := storage.New(cfg.Media)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "storage:", err)
+		return 1
+	}
+	a := &app.App{
+		FiberApp: fiber.New(),
+		Cfg:      cfg,
+		Bus:      eventbus.NewMemoryBus(),
+		Storage:  store,
+		Workers:  worker.NewRunner(),
+	}
+	if err := router.NewRouter().RegisterRouter(a); err != nil {
+		fmt.Fprintln(os.Stderr, "router:", err)
+		return 1
+	}
+
+	missing := openapi.Missing(a.FiberApp.GetRoutes(true), openapi.Operations)
+	for _, route := range missing {
+		fmt.Fprintln(os.Stderr, "missing from spec:", route)
+	}
+	if len(missing) > 0 {
+		return 1
+	}
+	fmt.Println("openapi: all routes documented")
+	return 0
+}
@@ -3,10 +3,21 @@ package main
 import (
 	"cleanArch_with_postgres/internal/infrastructure/app"
 	"cleanArch_with_postgres/internal/infrastructure/router"
+	"log/slog"
+	"os"
 )
 
 func main() {
+	// argümanla çalıştırılırsa sunucu yerine komut çalışır (bkz. commands.go)
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+
 	r := router.NewRouter()
-	a := app.New(r)
-	a.Start()
+	a, err := app.New(r)
+	if err != nil {
+		slog.Error("startup failed", "err", err)
+		os.Exit(app.ExitStartFailed)
+	}
+	os.Exit(a.Start())
 }
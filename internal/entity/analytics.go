@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// BlogView, tekilleştirme penceresinden geçen her okuma için tutulan ham kayıt.
+// ViewerKey: giriş yapmış kullanıcı için "u:<id>", diğerleri için "s:<session>".
+type BlogView struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	BlogID    uint      `gorm:"index:idx_blog_views_blog_viewer_time" json:"blog_id"`
+	ViewerKey string    `gorm:"type:varchar(100);index:idx_blog_views_blog_viewer_time" json:"viewer_key"`
+	ViewedAt  time.Time `gorm:"index:idx_blog_views_blog_viewer_time;index" json:"viewed_at"`
+}
+
+// BlogDailyStat, blog başına günlük okuma sayaçları
+type BlogDailyStat struct {
+	BlogID        uint      `gorm:"primaryKey;autoIncrement:false" json:"blog_id"`
+	Day           time.Time `gorm:"primaryKey;type:date" json:"day"`
+	Views         int64     `gorm:"not null;default:0" json:"views"`
+	UniqueReaders int64     `gorm:"not null;default:0" json:"unique_readers"`
+}
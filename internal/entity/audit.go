@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// Audit kayıtlarında kullanılan aksiyonlar
+const (
+	AuditErasureRequested = "user.erasure_requested"
+	AuditErasureCancelled = "user.erasure_cancelled"
+	AuditErasureCompleted = "user.erased"
+	AuditRetentionPurged  = "retention.purged"
+)
+
+// AuditLog, geri alınamayan ya da hesap verebilirlik gerektiren işlemlerin kaydı.
+// Actor kullanıcı adı ya da arka plan işleri için "system"; Details JSON metin.
+type AuditLog struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Actor      string    `gorm:"size:100;index" json:"actor"`
+	Action     string    `gorm:"size:64;index" json:"action"`
+	TargetType string    `gorm:"size:32" json:"target_type"`
+	TargetID   uint      `json:"target_id"`
+	Details    string    `gorm:"type:text" json:"details"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
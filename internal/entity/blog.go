@@ -6,4 +6,15 @@ type Blog struct {
 	Comments []Comment                        `json:"comments"`
 	Tags     string                           `json:"tags"`
 	Category string                           `json:"category"`
+	SEO      BlogSEO                          `gorm:"embedded;embeddedPrefix:seo_" json:"seo"`
+
+	ReactionCounts []BlogReactionCount `gorm:"foreignKey:BlogID" json:"reaction_counts"`
+}
+
+// BlogSEO, arama motorları ve sosyal paylaşımlar için yazara ait meta alanlar (boşsa varsayılanlar kullanılır)
+type BlogSEO struct {
+	MetaTitle       string `gorm:"size:120" json:"meta_title"`
+	MetaDescription string `gorm:"size:320" json:"meta_description"`
+	CanonicalURL    string `json:"canonical_url"`
+	OGImage         string `json:"og_image"`
 }
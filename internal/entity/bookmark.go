@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+type ReadingList struct {
+	BaseModel
+	UserID      uint   `gorm:"uniqueIndex:idx_reading_lists_user_slug,where:deleted_at IS NULL" json:"user_id"`
+	Username    string `gorm:"index" json:"username"`
+	Name        string `gorm:"type:varchar(100)" json:"name"`
+	Slug        string `gorm:"type:varchar(120);uniqueIndex:idx_reading_lists_user_slug,where:deleted_at IS NULL" json:"slug"`
+	Description string `json:"description"`
+	IsPublic    bool   `gorm:"default:false" json:"is_public"`
+}
+
+// Bookmark, kaydedilen blog. ReadingListID 0 ise herhangi bir listeye ait değildir.
+// BlogTitle, blog silinse bile listede ne olduğunu gösterebilmek için kayıt anındaki başlıktır.
+type Bookmark struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        uint      `gorm:"uniqueIndex:idx_bookmarks_user_list_blog" json:"user_id"`
+	ReadingListID uint      `gorm:"uniqueIndex:idx_bookmarks_user_list_blog;index;default:0" json:"reading_list_id"`
+	BlogID        uint      `gorm:"uniqueIndex:idx_bookmarks_user_list_blog;index" json:"blog_id"`
+	BlogTitle     string    `json:"blog_title"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -1,11 +1,69 @@
 package entity
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Blog statüleri (client: draft | published, silinince deleted)
+const (
+	BlogStatusDraft     = "draft"
+	BlogStatusPublished = "published"
+	BlogStatusDeleted   = "deleted"
+)
+
+// Body formatları; eski kayıtlar düz metin olduğundan varsayılan plain
+const (
+	BodyFormatMarkdown = "markdown"
+	BodyFormatHTML     = "html"
+	BodyFormatPlain    = "plain"
+)
+
 type Content struct {
 	Title      string `json:"title"`
 	Body       string `json:"body"`
+	BodyFormat string `gorm:"size:16;default:plain" json:"body_format"`
 	AuthorID   int    `json:"author_id"`
 	Username   string `json:"username"`
 	Type       string `json:"type"`
 	IsApproved bool   `json:"is_approved"`
 	Status     string `json:"status"`
+
+	// Body'den türetilen alanlar, her create/update'te yeniden hesaplanır
+	BodyHTML    string `gorm:"type:text" json:"body_html"`
+	Excerpt     string `gorm:"size:400" json:"excerpt"`
+	WordCount   int    `json:"word_count"`
+	ReadingTime int    `json:"reading_time"` // dakika
+	TOC         TOC    `gorm:"type:jsonb" json:"toc"`
+}
+
+// TOCEntry, başlık tabanlı içindekiler satırı; ID, BodyHTML içindeki başlığın id'si
+type TOCEntry struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+type TOC []TOCEntry
+
+func (t TOC) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(t)
+	return string(b), err
+}
+
+func (t *TOC) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	}
+	return errors.New("unsupported toc type")
 }
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+const ImportSourceWXR = "wxr"
+
+// İçe aktarılan kayıt türleri
+const (
+	ImportKindUser    = "user"
+	ImportKindPost    = "post"
+	ImportKindComment = "comment"
+)
+
+// ImportMapping, dış kaynaktaki bir kaydın bizdeki karşılığı. Aynı export tekrar içe
+// aktarıldığında buradan bulunup atlanır, böylece içerik çoğalmaz.
+type ImportMapping struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Source     string    `gorm:"size:32;uniqueIndex:idx_import_mappings_source" json:"source"`
+	SourceSite string    `gorm:"size:255;uniqueIndex:idx_import_mappings_source" json:"source_site"` // WXR için sitenin adresi
+	Kind       string    `gorm:"size:32;uniqueIndex:idx_import_mappings_source" json:"kind"`
+	SourceID   string    `gorm:"size:64;uniqueIndex:idx_import_mappings_source" json:"source_id"`
+	TargetID   uint      `json:"target_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Media, kullanıcının yüklediği görsel. Dosyanın kendisi storage'da, burada sadece metadata tutulur.
+type Media struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID       uint      `gorm:"index" json:"user_id"`
+	Username     string    `json:"username"`
+	Key          string    `gorm:"uniqueIndex;size:255" json:"key"`
+	ThumbKey     string    `gorm:"size:255" json:"thumb_key"`
+	OriginalName string    `json:"original_name"`
+	ContentType  string    `gorm:"size:64" json:"content_type"`
+	Size         int64     `json:"size"` // byte, küçük resim dahil değil
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	CreatedAt    time.Time `gorm:"index" json:"created_at"`
+}
+
+// BlogMedia, blog gövdesinde referans verilen medya; blog her kaydedildiğinde yeniden hesaplanır.
+// Hiçbir blogla bağı olmayan medya GC tarafından silinir.
+type BlogMedia struct {
+	BlogID  uint `gorm:"primaryKey;autoIncrement:false" json:"blog_id"`
+	MediaID uint `gorm:"primaryKey;autoIncrement:false;index" json:"media_id"`
+}
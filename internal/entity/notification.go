@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type Notification struct {
+	BaseModel
+	Username string     `gorm:"index" json:"username"` // bildirimi alan kullanıcı
+	Type     string     `json:"type"`
+	Message  string     `json:"message"`
+	Link     string     `json:"link"`
+	ReadAt   *time.Time `json:"read_at"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// Hesap silme (erasure) talebi durumları
+const (
+	ErasurePending   = "pending"
+	ErasureCompleted = "completed"
+	ErasureCancelled = "cancelled"
+)
+
+// Erasure sırasında kullanıcının yazılarına uygulanacak politika
+const (
+	ErasurePolicyReassign = "reassign" // yazılar ve yorumlar placeholder kullanıcıya geçer
+	ErasurePolicyDelete   = "delete"   // yazılar ve yorumlar kalıcı olarak silinir
+)
+
+// ErasureRequest, kullanıcının hesabının anonimleştirilmesi talebi. ScheduledAt'e kadar
+// iptal edilebilir, sonrasında arka plan işi tarafından uygulanır.
+type ErasureRequest struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uint       `gorm:"index" json:"user_id"`
+	Status      string     `gorm:"size:16;index" json:"status"`
+	ScheduledAt time.Time  `gorm:"index" json:"scheduled_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
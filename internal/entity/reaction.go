@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const ReactionLike = "like"
+
+// Reaction, bir kullanıcının bir bloga verdiği tepki. Aynı tipten blog başına bir tane olabilir.
+type Reaction struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	BlogID    uint      `gorm:"uniqueIndex:idx_reactions_blog_user_type" json:"blog_id"`
+	UserID    uint      `gorm:"uniqueIndex:idx_reactions_blog_user_type;index:idx_reactions_user_type" json:"user_id"`
+	Type      string    `gorm:"type:varchar(32);uniqueIndex:idx_reactions_blog_user_type;index:idx_reactions_user_type" json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BlogReactionCount, popüler bloglarda count(*) atmamak için tutulan sayaç tablosu.
+// Reaction eklenip silinirken aynı transaction içinde güncellenir.
+type BlogReactionCount struct {
+	BlogID uint   `gorm:"primaryKey;autoIncrement:false" json:"blog_id"`
+	Type   string `gorm:"primaryKey;type:varchar(32)" json:"type"`
+	Count  int64  `gorm:"not null;default:0" json:"count"`
+}
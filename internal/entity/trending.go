@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+const (
+	TrendingDay   = "day"
+	TrendingWeek  = "week"
+	TrendingMonth = "month"
+)
+
+// TrendingScore, arka plan işi tarafından artımlı olarak güncellenen zamanla sönümlenen skor
+type TrendingScore struct {
+	BlogID         uint      `gorm:"primaryKey;autoIncrement:false" json:"blog_id"`
+	Period         string    `gorm:"primaryKey;type:varchar(10);index:idx_trending_period_score,priority:1" json:"period"` // day | week | month
+	Score          float64   `gorm:"index:idx_trending_period_score,priority:2,sort:desc" json:"score"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// JobCheckpoint, periyodik işlerin en son nereye kadar işlediğini tutar
+type JobCheckpoint struct {
+	Name string    `gorm:"primaryKey;type:varchar(100)" json:"name"`
+	At   time.Time `json:"at"`
+}
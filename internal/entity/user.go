@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type UserRole string
 
 const (
@@ -15,4 +17,13 @@ type User struct {
 	Password  string   `gorm:"type:varchar(100)" json:"-"`
 	Role      UserRole `gorm:"type:varchar(100)" json:"role"`
 	Followers []string `gorm:"type:varchar(100)" json:"-"`
+
+	// Locale, API mesajlarının dili (ör. "tr", "en"); boşsa Accept-Language kullanılır
+	Locale string `gorm:"type:varchar(16);not null;default:''" json:"locale"`
+
+	// MustResetPassword, içe aktarılan (ör. WordPress) kullanıcılar için; şifre değişene kadar sadece /me kullanılabilir
+	MustResetPassword bool `gorm:"not null;default:false" json:"must_reset_password"`
+
+	// ErasedAt, hesap anonimleştirildiyse dolu; bu kullanıcılar geri yüklenemez
+	ErasedAt *time.Time `json:"erased_at,omitempty"`
 }
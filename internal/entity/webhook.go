@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// Webhook ile dışarıya gönderilen event tipleri
+const (
+	WebhookEventAll                = "*"
+	WebhookEventBlogPublished      = "blog.published"
+	WebhookEventUserRegistered     = "user.registered"
+	WebhookEventRoleRequestDecided = "role_request.decided"
+)
+
+var WebhookEventTypes = []string{
+	WebhookEventBlogPublished,
+	WebhookEventUserRegistered,
+	WebhookEventRoleRequestDecided,
+}
+
+type Webhook struct {
+	BaseModel
+	URL       string `gorm:"type:varchar(2048)" json:"url"`
+	Secret    string `gorm:"type:varchar(255)" json:"-"`
+	Events    string `json:"events"` // virgülle ayrılmış, "*" hepsi (blog Tags ile aynı format)
+	Active    bool   `gorm:"default:true" json:"active"`
+	CreatedBy string `json:"created_by"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed" // deneme hakkı bitti
+)
+
+type WebhookDelivery struct {
+	BaseModel
+	WebhookID      uint                  `gorm:"index" json:"webhook_id"`
+	EventID        string                `gorm:"type:varchar(64);index" json:"event_id"`
+	EventType      string                `json:"event_type"`
+	Payload        string                `gorm:"type:text" json:"payload"`
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(20);index" json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `gorm:"index" json:"next_attempt_at"`
+	LastStatusCode int                   `json:"last_status_code"`
+	LastError      string                `gorm:"type:text" json:"last_error"`
+	DeliveredAt    *time.Time            `json:"delivered_at"`
+	ReplayOf       *uint                 `json:"replay_of"`
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/service"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const sessionHeader = "X-Session-Id"
+
+type AnalyticsHandler struct {
+	ans service.AnalyticsService
+}
+
+func NewAnalyticsHandler(ans service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{ans: ans}
+}
+
+// AuthorReport: ?from=YYYY-MM-DD&to=YYYY-MM-DD (varsayılan son 30 gün)
+func (h *AnalyticsHandler) AuthorReport(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	resp, err := h.ans.AuthorReport(c.UserContext(), username, c.Query("from"), c.Query("to"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp})
+}
+
+// SiteReport (admin): ?from=&to=&author= (author boşsa tüm site)
+func (h *AnalyticsHandler) SiteReport(c *fiber.Ctx) error {
+	role, _ := c.Locals("role").(string)
+	username, _ := c.Locals("username").(string)
+	if role != "admin" {
+		return service.ErrNotAllowed
+	}
+	resp, err := h.ans.SiteReport(c.UserContext(), username, c.Query("author"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp})
+}
+
+// viewerKey: token'dan user_id, yoksa X-Session-Id header'ı, o da yoksa IP + User-Agent özeti
+func viewerKey(c *fiber.Ctx) string {
+	if uid, ok := c.Locals("user_id").(uint); ok && uid != 0 {
+		return service.UserViewerKey(uid)
+	}
+	if sid := c.Get(sessionHeader); sid != "" {
+		if len(sid) > 64 {
+			sid = sid[:64]
+		}
+		return "s:" + sid
+	}
+	sum := sha256.Sum256([]byte(c.IP() + "|" + c.Get(fiber.HeaderUserAgent)))
+	return "s:" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"bufio"
+	"cleanArch_with_postgres/internal/middleware"
+	"cleanArch_with_postgres/internal/service"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// archiveMaxUpload, import edilen zip için sınır (içindeki dosyalar ayrıca sınırlanır)
+const archiveMaxUpload = 10 << 20
+
+type ArchiveHandler struct {
+	as service.ArchiveService
+}
+
+func NewArchiveHandler(as service.ArchiveService) *ArchiveHandler {
+	return &ArchiveHandler{as: as}
+}
+
+func (h *ArchiveHandler) ExportBlogs(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	write, err := h.as.ExportBlogs(c.UserContext(), username)
+	if err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("%s-blogs-%s.zip", username, time.Now().Format("20060102"))
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx := c.UserContext()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := write(w); err != nil {
+			slog.ErrorContext(ctx, "blog export error", "err", err)
+		}
+		_ = w.Flush()
+	})
+	return nil
+}
+
+// ImportBlogs, multipart "archive" alanında zip bekler
+func (h *ArchiveHandler) ImportBlogs(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	fh, err := c.FormFile("archive")
+	if err != nil {
+		return service.Validation("archive_required", "archive required")
+	}
+	if fh.Size > archiveMaxUpload {
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, "archive is too large")
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return service.Validation("archive_unreadable", "archive read error").Wrap(err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, archiveMaxUpload+1))
+	if err != nil {
+		return service.Validation("archive_unreadable", "archive read error").Wrap(err)
+	}
+
+	report, err := h.as.ImportBlogs(c.UserContext(), username, middleware.LocaleOf(c), data)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": report})
+}
@@ -3,7 +3,6 @@ package handler
 import (
 	"cleanArch_with_postgres/internal/service"
 	"cleanArch_with_postgres/internal/viewmodel"
-	"context"
 	"strconv"
 	"strings"
 
@@ -21,59 +20,65 @@ func NewAuthHandler(as service.AuthService) *AuthHandler {
 func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	var input viewmodel.RegisterRequest
 	if err := c.BodyParser(&input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input"})
+		return service.ErrInvalidInput.Wrap(err)
 	}
 
 	input.Role = strings.ToLower(strings.TrimSpace(input.Role))
+	if err := service.Validate(&input); err != nil {
+		return err
+	}
 
-	resp, err := h.as.Register(context.Background(), input)
+	resp, err := h.as.Register(c.UserContext(), input)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 
 	if input.Role == "admin" {
 		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 			"data":    resp,
-			"message": "User created successfully! Admin rolü isteğiniz adminlere gönderildi. Admin onayına göre rolünüz belirlenecek. Not: Şu anda rolünüz 'reader' olarak kaydedildi",
+			"message": msg(c, "user_registered_admin_pending"),
 		})
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"data":    resp,
-		"message": "User created successfully",
+		"message": msg(c, "user_registered"),
 	})
 }
 
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var input viewmodel.LoginRequest
 	if err := c.BodyParser(&input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input"})
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
 	}
 
-	resp, err := h.as.Login(context.Background(), input.Identifier, input.Password)
+	resp, err := h.as.Login(c.UserContext(), input.Identifier, input.Password)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data":    resp,
-		"message": "User login successfully!",
+		"message": msg(c, "user_logged_in"),
 	})
 }
 
 func (h *AuthHandler) GetUserByUsername(c *fiber.Ctx) error {
 	paramUsername := strings.TrimSpace(c.Params("username"))
 	if paramUsername == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "username required"})
+		return service.Validation("username_required", "username required")
 	}
 	tokenUsername, _ := c.Locals("username").(string)
 	if tokenUsername == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		return service.ErrInvalidToken
 	}
 
-	resp, err := h.as.GetUserVMByUsername(context.Background(), paramUsername, tokenUsername)
+	resp, err := h.as.GetUserVMByUsername(c.UserContext(), paramUsername, tokenUsername)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp})
@@ -90,9 +95,9 @@ func (h *AuthHandler) SearchUsers(c *fiber.Ctx) error {
 
 	viewerUsername, _ := c.Locals("username").(string) // token’daki kullanıcı adı
 
-	res, err := h.as.SearchUsersWithOptions(context.Background(), viewerUsername, q, limit, includeDeleted)
+	res, err := h.as.SearchUsersWithOptions(c.UserContext(), viewerUsername, q, limit, includeDeleted)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": res})
 }
@@ -101,78 +106,81 @@ func (h *AuthHandler) RestoreUser(c *fiber.Ctx) error {
 	// sadece admin
 	role, _ := c.Locals("role").(string)
 	if role != "admin" {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "not allowed"})
+		return service.ErrNotAllowed
 	}
 
 	username := strings.TrimSpace(c.Params("username"))
 	if username == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "username required"})
+		return service.Validation("username_required", "username required")
 	}
 
-	if err := h.as.RestoreUser(context.Background(), username); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	if err := h.as.RestoreUser(c.UserContext(), username); err != nil {
+		return err
 	}
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User restored successfully!"})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "user_restored")})
 }
 
 func (h *AuthHandler) UpdateUser(c *fiber.Ctx) error {
 	tokenUsername, _ := c.Locals("username").(string)
 	if tokenUsername == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		return service.ErrInvalidToken
 	}
 	tokenRole, _ := c.Locals("role").(string)
 
 	paramUsername := strings.TrimSpace(c.Params("username"))
 	if paramUsername == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "username required"})
+		return service.Validation("username_required", "username required")
 	}
 	target := paramUsername
 
 	if tokenRole != "admin" {
 		if tokenUsername != paramUsername {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "not allowed"})
+			return service.ErrNotAllowed
 		}
 		target = tokenUsername
 	}
 
 	var input viewmodel.UpdateRequest
 	if err := c.BodyParser(&input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input"})
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
 	}
 
-	resp, err := h.as.UpdateUser(context.Background(), target, &input)
+	resp, err := h.as.UpdateUser(c.UserContext(), target, &input)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp, "message": "User updated successfully!"})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp, "message": msg(c, "user_updated")})
 }
 
 func (h *AuthHandler) DeleteUser(c *fiber.Ctx) error {
 	tokenUsername, _ := c.Locals("username").(string)
 	if tokenUsername == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		return service.ErrInvalidToken
 	}
 	tokenRole, _ := c.Locals("role").(string)
 
 	paramUsername := strings.TrimSpace(c.Params("username"))
 	if paramUsername == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "username required"})
+		return service.Validation("username_required", "username required")
 	}
 	target := paramUsername
 
 	if tokenRole != "admin" {
 		if tokenUsername != paramUsername {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "not allowed"})
+			return service.ErrNotAllowed
 		}
 		target = tokenUsername
 	}
 
-	if err := h.as.DeleteUser(context.Background(), target); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	if err := h.as.DeleteUser(c.UserContext(), target); err != nil {
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User deleted successfully!"})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "user_deleted")})
 }
 
 // ---------- ROLE REQUESTS ----------
@@ -180,25 +188,28 @@ func (h *AuthHandler) DeleteUser(c *fiber.Ctx) error {
 func (h *AuthHandler) RequestAdminRole(c *fiber.Ctx) error {
 	tokenUsername, _ := c.Locals("username").(string)
 	if tokenUsername == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		return service.ErrInvalidToken
 	}
 
 	var body struct {
-		Reason string `json:"reason"`
+		Reason string `json:"reason" validate:"max=500"`
 	}
 	_ = c.BodyParser(&body)
+	if err := service.Validate(&body); err != nil {
+		return err
+	}
 
-	vm, err := h.as.RequestAdminRole(context.Background(), tokenUsername, body.Reason)
+	vm, err := h.as.RequestAdminRole(c.UserContext(), tokenUsername, body.Reason)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": vm, "message": "Talebiniz alındı"})
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": vm, "message": msg(c, "role_request_received")})
 }
 
 func (h *AuthHandler) ListRoleRequests(c *fiber.Ctx) error {
 	role, _ := c.Locals("role").(string)
 	if role != "admin" {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "not allowed"})
+		return service.ErrNotAllowed
 	}
 	status := c.Query("status")
 	limitStr := c.Query("limit", "100")
@@ -207,9 +218,9 @@ func (h *AuthHandler) ListRoleRequests(c *fiber.Ctx) error {
 		limit = 100
 	}
 
-	list, err := h.as.ListRoleRequests(context.Background(), status, limit)
+	list, err := h.as.ListRoleRequests(c.UserContext(), status, limit)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": list})
 }
@@ -218,32 +229,32 @@ func (h *AuthHandler) ApproveRoleRequest(c *fiber.Ctx) error {
 	role, _ := c.Locals("role").(string)
 	admin, _ := c.Locals("username").(string)
 	if role != "admin" {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "not allowed"})
+		return service.ErrNotAllowed
 	}
 	id64, err := strconv.ParseUint(c.Params("id"), 10, 64)
 	if err != nil || id64 == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		return service.ErrInvalidID
 	}
-	if err := h.as.ApproveRoleRequest(context.Background(), uint(id64), admin); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	if err := h.as.ApproveRoleRequest(c.UserContext(), uint(id64), admin); err != nil {
+		return err
 	}
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Talep onaylandı"})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "role_request_approved")})
 }
 
 func (h *AuthHandler) RejectRoleRequest(c *fiber.Ctx) error {
 	role, _ := c.Locals("role").(string)
 	admin, _ := c.Locals("username").(string)
 	if role != "admin" {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "not allowed"})
+		return service.ErrNotAllowed
 	}
 	id64, err := strconv.ParseUint(c.Params("id"), 10, 64)
 	if err != nil || id64 == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid id"})
+		return service.ErrInvalidID
 	}
-	if err := h.as.RejectRoleRequest(context.Background(), uint(id64), admin); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	if err := h.as.RejectRoleRequest(c.UserContext(), uint(id64), admin); err != nil {
+		return err
 	}
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Talep reddedildi"})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "role_request_rejected")})
 }
 
 // ---------- ME ENDPOİNTLERİ ----------
@@ -251,11 +262,11 @@ func (h *AuthHandler) RejectRoleRequest(c *fiber.Ctx) error {
 func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
 	username, _ := c.Locals("username").(string)
 	if username == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		return service.ErrInvalidToken
 	}
-	vm, err := h.as.GetUserVMByUsername(context.Background(), username, username)
+	vm, err := h.as.GetUserVMByUsername(c.UserContext(), username, username)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": vm})
 }
@@ -263,26 +274,29 @@ func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
 func (h *AuthHandler) UpdateMe(c *fiber.Ctx) error {
 	username, _ := c.Locals("username").(string)
 	if username == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		return service.ErrInvalidToken
 	}
 	var in viewmodel.UpdateRequest
 	if err := c.BodyParser(&in); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid input"})
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&in); err != nil {
+		return err
 	}
-	resp, err := h.as.UpdateUser(context.Background(), username, &in)
+	resp, err := h.as.UpdateUser(c.UserContext(), username, &in)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp, "message": "User updated successfully!"})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp, "message": msg(c, "user_updated")})
 }
 
 func (h *AuthHandler) DeleteMe(c *fiber.Ctx) error {
 	username, _ := c.Locals("username").(string)
 	if username == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		return service.ErrInvalidToken
 	}
-	if err := h.as.DeleteUser(context.Background(), username); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	if err := h.as.DeleteUser(c.UserContext(), username); err != nil {
+		return err
 	}
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User deleted successfully!"})
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "user_deleted")})
 }
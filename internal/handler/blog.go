@@ -3,46 +3,44 @@ package handler
 import (
 	"cleanArch_with_postgres/internal/service"
 	"cleanArch_with_postgres/internal/viewmodel"
-	"context"
+	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type BlogHandler struct {
-	bs service.BlogService
+	bs  service.BlogService
+	ans service.AnalyticsService
+	ts  service.TrendingService
 }
 
-func NewBlogHandler(bs service.BlogService) *BlogHandler {
-	return &BlogHandler{bs: bs}
+func NewBlogHandler(bs service.BlogService, ans service.AnalyticsService, ts service.TrendingService) *BlogHandler {
+	return &BlogHandler{bs: bs, ans: ans, ts: ts}
 }
 
 func (h *BlogHandler) CreateBlog(c *fiber.Ctx) error {
 	var input viewmodel.BlogCreateVM
 	err := c.BodyParser(&input)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid input json",
-			"message": err.Error(),
-		})
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
 	}
 
 	username, ok := c.Locals("username").(string)
 	if !ok || username == "" { // ok la username ayır
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid token username",
-		})
+		return service.ErrInvalidToken
 	}
 
-	err = h.bs.CreateBlog(context.Background(), &input, username)
+	err = h.bs.CreateBlog(c.UserContext(), &input, username)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"message":  "Blog created successfully",
+		"message":  msg(c, "blog_created"),
 		"username": username,
 	})
 }
@@ -50,60 +48,48 @@ func (h *BlogHandler) CreateBlog(c *fiber.Ctx) error {
 func (h *BlogHandler) UpdateBlog(c *fiber.Ctx) error {
 	title := c.Params("title") // param title
 	if title == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "title required",
-		})
+		return service.ErrInvalidTitle
 	}
 
 	username, ok := c.Locals("username").(string) // token username
 	if !ok || username == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid token username",
-		})
+		return service.ErrInvalidToken
 	}
 
 	var input viewmodel.BlogUpdateVM
 	if err := c.BodyParser(&input); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid input json",
-			"message": err.Error(),
-		})
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
 	}
 
-	resp, err := h.bs.UpdateBlog(context.Background(), title, username, &input)
+	resp, err := h.bs.UpdateBlog(c.UserContext(), title, username, &input)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data":    resp,
-		"message": "Blog updated successfully",
+		"message": msg(c, "blog_updated"),
 	})
 }
 
 func (h *BlogHandler) DeleteBlog(c *fiber.Ctx) error {
 	title := c.Params("title") // param title		/	undecodedTitle
 	if title == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid title param",
-		})
+		return service.ErrInvalidTitle
 	}
 	username, ok := c.Locals("username").(string)
 	if !ok || username == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid token username",
-		})
+		return service.ErrInvalidToken
 	}
-	decodedTitle, err := h.bs.DeleteBlog(context.Background(), title, username)
+	decodedTitle, err := h.bs.DeleteBlog(c.UserContext(), title, username)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message":     "Blog deleted successfully",
+		"message":     msg(c, "blog_deleted"),
 		"title":       decodedTitle,
 		"blog_author": username,
 	})
@@ -112,7 +98,7 @@ func (h *BlogHandler) DeleteBlog(c *fiber.Ctx) error {
 func (h *BlogHandler) GetAllBlogs(c *fiber.Ctx) error {
 	username, ok := c.Locals("username").(string) // token username
 	if !ok || username == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid token username"})
+		return service.ErrInvalidToken
 	}
 
 	includeDeleted := false
@@ -123,9 +109,19 @@ func (h *BlogHandler) GetAllBlogs(c *fiber.Ctx) error {
 		}
 	}
 
-	resp, err := h.bs.GetAllBlogsWithOptions(context.Background(), username, includeDeleted)
+	resp, err := h.bs.GetAllBlogsWithOptions(c.UserContext(), username, includeDeleted)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp})
+}
+
+// GetTrending: ?window=day|week|month (varsayılan week)&limit=20
+func (h *BlogHandler) GetTrending(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	resp, err := h.ts.GetTrending(c.UserContext(), c.Query("window"), limit)
+	if err != nil {
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp})
 }
@@ -133,11 +129,11 @@ func (h *BlogHandler) GetAllBlogs(c *fiber.Ctx) error {
 func (h *BlogHandler) GetBlogsByAuthor(c *fiber.Ctx) error {
 	paramUsername := c.Params("username") // param username
 	if paramUsername == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid param username"})
+		return service.ErrInvalidUser
 	}
 	tokenUsername, ok := c.Locals("username").(string)
 	if !ok || tokenUsername == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid token username"})
+		return service.ErrInvalidToken
 	}
 
 	username := paramUsername
@@ -149,12 +145,12 @@ func (h *BlogHandler) GetBlogsByAuthor(c *fiber.Ctx) error {
 	inc := strings.ToLower(c.Query("include_deleted"))
 	includeDeleted := inc == "1" || inc == "true" || inc == "yes"
 
-	resp, err := h.bs.GetBlogsByAuthor(context.Background(), username, tokenUsername, includeDeleted)
+	resp, err := h.bs.GetBlogsByAuthor(c.UserContext(), username, tokenUsername, includeDeleted)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Blogs Author: " + paramUsername,
+		"message": msg(c, "blogs_by_author", "author", paramUsername),
 		"data":    resp,
 	})
 }
@@ -162,21 +158,21 @@ func (h *BlogHandler) GetBlogsByAuthor(c *fiber.Ctx) error {
 func (h *BlogHandler) GetBlogsByAuthorIncludeDeleted(c *fiber.Ctx) error {
 	paramUsername := c.Params("username")
 	if paramUsername == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid username"})
+		return service.ErrInvalidUser
 	}
 
 	username := paramUsername
 	if strings.EqualFold(paramUsername, "me") {
 		tokenUsername, _ := c.Locals("username").(string)
 		if tokenUsername == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+			return service.ErrInvalidToken
 		}
 		username = tokenUsername
 	}
 
-	resp, err := h.bs.GetBlogsByAuthorIncludeDeleted(context.Background(), username)
+	resp, err := h.bs.GetBlogsByAuthorIncludeDeleted(c.UserContext(), username)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data": resp,
@@ -186,42 +182,46 @@ func (h *BlogHandler) GetBlogsByAuthorIncludeDeleted(c *fiber.Ctx) error {
 func (h *BlogHandler) GetBlogByTitle(c *fiber.Ctx) error {
 	title := c.Params("title")
 	if title == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid title"})
+		return service.ErrInvalidTitle
 	}
 	username, ok := c.Locals("username").(string)
 	if !ok || username == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid token username"})
+		return service.ErrInvalidToken
 	}
-	resp, err := h.bs.GetBlogByTitle(context.Background(), title, username)
+	resp, err := h.bs.GetBlogByTitle(c.UserContext(), title, username)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
+
+	// okuma kaydı hatası cevabı etkilemesin
+	_ = h.ans.RecordView(c.UserContext(), resp.ID, uint(resp.AuthorID), viewerKey(c))
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp})
 }
 
 func (h *BlogHandler) ApproveBlog(c *fiber.Ctx) error {
 	title := c.Params("title")
 	username, _ := c.Locals("username").(string)
-	if err := h.bs.ApproveBlog(context.Background(), title, username, true); err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	if err := h.bs.ApproveBlog(c.UserContext(), title, username, true); err != nil {
+		return err
 	}
-	return c.JSON(fiber.Map{"message": "approved"})
+	return c.JSON(fiber.Map{"message": msg(c, "blog_approved")})
 }
 
 func (h *BlogHandler) UnapproveBlog(c *fiber.Ctx) error {
 	title := c.Params("title")
 	username, _ := c.Locals("username").(string)
-	if err := h.bs.ApproveBlog(context.Background(), title, username, false); err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	if err := h.bs.ApproveBlog(c.UserContext(), title, username, false); err != nil {
+		return err
 	}
-	return c.JSON(fiber.Map{"message": "unapproved"})
+	return c.JSON(fiber.Map{"message": msg(c, "blog_unapproved")})
 }
 
 func (h *BlogHandler) RestoreBlog(c *fiber.Ctx) error {
 	title := c.Params("title")
 	username, _ := c.Locals("username").(string)
-	if err := h.bs.RestoreBlog(context.Background(), title, username); err != nil {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	if err := h.bs.RestoreBlog(c.UserContext(), title, username); err != nil {
+		return err
 	}
-	return c.JSON(fiber.Map{"message": "restored"})
+	return c.JSON(fiber.Map{"message": msg(c, "blog_restored")})
 }
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BookmarkHandler struct {
+	bms service.BookmarkService
+}
+
+func NewBookmarkHandler(bms service.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{bms: bms}
+}
+
+func (h *BookmarkHandler) ListBookmarks(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+
+	// ?list_id=0 listesiz bookmark'lar, parametre yoksa hepsi
+	var listID *uint
+	if v := c.Query("list_id"); v != "" {
+		id64, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return service.Validation("invalid_list_id", "invalid list_id")
+		}
+		id := uint(id64)
+		listID = &id
+	}
+
+	list, err := h.bms.ListBookmarks(c.UserContext(), username, listID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": list})
+}
+
+func (h *BookmarkHandler) CreateBookmark(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	var input viewmodel.BookmarkCreateVM
+	if err := c.BodyParser(&input); err != nil {
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
+	}
+
+	vm, err := h.bms.CreateBookmark(c.UserContext(), username, &input)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": vm, "message": msg(c, "bookmark_created")})
+}
+
+func (h *BookmarkHandler) UpdateBookmark(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	var input viewmodel.BookmarkUpdateVM
+	if err := c.BodyParser(&input); err != nil {
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
+	}
+
+	vm, err := h.bms.UpdateBookmark(c.UserContext(), username, id, &input)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": vm})
+}
+
+func (h *BookmarkHandler) DeleteBookmark(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	if err := h.bms.DeleteBookmark(c.UserContext(), username, id); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "bookmark_deleted")})
+}
+
+func (h *BookmarkHandler) ListMyReadingLists(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	list, err := h.bms.ListMyReadingLists(c.UserContext(), username)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": list})
+}
+
+func (h *BookmarkHandler) CreateReadingList(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	var input viewmodel.ReadingListCreateVM
+	if err := c.BodyParser(&input); err != nil {
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
+	}
+
+	vm, err := h.bms.CreateReadingList(c.UserContext(), username, &input)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": vm, "message": msg(c, "reading_list_created")})
+}
+
+func (h *BookmarkHandler) GetReadingList(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	vm, err := h.bms.GetReadingList(c.UserContext(), username, id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": vm})
+}
+
+func (h *BookmarkHandler) UpdateReadingList(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	var input viewmodel.ReadingListUpdateVM
+	if err := c.BodyParser(&input); err != nil {
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
+	}
+
+	vm, err := h.bms.UpdateReadingList(c.UserContext(), username, id, &input)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": vm})
+}
+
+func (h *BookmarkHandler) DeleteReadingList(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	if err := h.bms.DeleteReadingList(c.UserContext(), username, id); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "reading_list_deleted")})
+}
+
+func (h *BookmarkHandler) ListPublicReadingLists(c *fiber.Ctx) error {
+	owner := c.Params("username")
+	if owner == "" {
+		return service.Validation("username_required", "username required")
+	}
+	list, err := h.bms.ListPublicReadingLists(c.UserContext(), owner)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": list})
+}
+
+func (h *BookmarkHandler) GetPublicReadingList(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	vm, err := h.bms.GetPublicReadingList(c.UserContext(), username, c.Params("username"), c.Params("slug"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": vm})
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/openapi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DocsHandler struct {
+	spec []byte
+}
+
+// NewDocsHandler, açılışta bir kez üretilen OpenAPI dokümanını sunar
+func NewDocsHandler(spec []byte) *DocsHandler {
+	return &DocsHandler{spec: spec}
+}
+
+func (h *DocsHandler) Spec(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Status(fiber.StatusOK).Send(h.spec)
+}
+
+func (h *DocsHandler) UI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.Status(fiber.StatusOK).Send(openapi.DocsHTML)
+}
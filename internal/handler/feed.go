@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/middleware"
+	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FeedHandler struct {
+	fs service.FeedService
+}
+
+func NewFeedHandler(fs service.FeedService) *FeedHandler {
+	return &FeedHandler{fs: fs}
+}
+
+func (h *FeedHandler) SiteFeed(c *fiber.Ctx) error {
+	feed, err := h.fs.SiteFeed(c.UserContext())
+	return h.respond(c, feed, err)
+}
+
+func (h *FeedHandler) AuthorFeed(c *fiber.Ctx) error {
+	feed, err := h.fs.AuthorFeed(c.UserContext(), middleware.LocaleOf(c), c.Params("username"))
+	return h.respond(c, feed, err)
+}
+
+func (h *FeedHandler) TagFeed(c *fiber.Ctx) error {
+	feed, err := h.fs.TagFeed(c.UserContext(), middleware.LocaleOf(c), c.Params("tag"))
+	return h.respond(c, feed, err)
+}
+
+func (h *FeedHandler) CategoryFeed(c *fiber.Ctx) error {
+	feed, err := h.fs.CategoryFeed(c.UserContext(), middleware.LocaleOf(c), c.Params("category"))
+	return h.respond(c, feed, err)
+}
+
+// respond, ?format=rss|atom (varsayılan rss) ile feed'i yazar; ETag/Last-Modified ile 304 döner
+func (h *FeedHandler) respond(c *fiber.Ctx, feed *viewmodel.FeedVM, err error) error {
+	if err != nil {
+		return err
+	}
+
+	format := strings.ToLower(c.Query("format", "rss"))
+	var contentType string
+	switch format {
+	case "rss":
+		contentType = "application/rss+xml; charset=utf-8"
+	case "atom":
+		contentType = "application/atom+xml; charset=utf-8"
+	default:
+		return service.Validation("invalid_feed_format", "invalid format, expected rss|atom")
+	}
+
+	etag := feedETag(format, feed)
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	c.Vary(fiber.HeaderAcceptLanguage) // açıklama isteğin dilinde
+	lastModified := feed.LastModified()
+	if !lastModified.IsZero() {
+		c.Set(fiber.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+	if notModified(c, etag, lastModified) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	var body []byte
+	if format == "atom" {
+		body, err = feed.Atom()
+	} else {
+		body, err = feed.RSS()
+	}
+	if err != nil {
+		return fmt.Errorf("feed render error: %w", err)
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Status(fiber.StatusOK).Send(body)
+}
+
+// feedETag, içerik değişince (yeni yazı, güncelleme, silme) değişen zayıf ETag
+func feedETag(format string, feed *viewmodel.FeedVM) string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "%s|%s|%s|%s", format, feed.Title, feed.Description, feed.SelfLink)
+	for _, it := range feed.Items {
+		fmt.Fprintf(hash, "|%s|%d", it.GUID, it.Updated.UnixNano())
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)) + `"`
+}
+
+// notModified, If-None-Match varsa yalnızca ona bakar; yoksa If-Modified-Since'e (RFC 7232 §6)
+func notModified(c *fiber.Ctx, etag string, lastModified time.Time) bool {
+	if inm := c.Get(fiber.HeaderIfNoneMatch); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || tag != "" && strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := c.Get(fiber.HeaderIfModifiedSince); ims != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(ims)
+		if err == nil && !lastModified.Truncate(time.Second).After(t) {
+			return true
+		}
+	}
+	return false
+}
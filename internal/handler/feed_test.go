@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/viewmodel"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func testFeed() *viewmodel.FeedVM {
+	t1 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	return &viewmodel.FeedVM{
+		Title:    "Blog",
+		SelfLink: "http://localhost/feeds",
+		Updated:  t2,
+		Items: []viewmodel.FeedItemVM{
+			{GUID: "urn:blog:2", Updated: t2},
+			{GUID: "urn:blog:1", Updated: t1},
+		},
+	}
+}
+
+func TestFeedETag(t *testing.T) {
+	base := feedETag("rss", testFeed())
+	if base != feedETag("rss", testFeed()) {
+		t.Fatal("etag not stable for the same feed")
+	}
+
+	// en yeni yazı yayından kalkınca Updated geriye gider; ETag yine de değişmeli
+	unpublished := testFeed()
+	unpublished.Items = unpublished.Items[1:]
+	unpublished.Updated = unpublished.Items[0].Updated
+
+	described := testFeed()
+	described.Description = "Açıklama"
+
+	edited := testFeed()
+	edited.Items[1].Updated = edited.Items[1].Updated.Add(time.Minute)
+
+	for name, tag := range map[string]string{
+		"atom format":   feedETag("atom", testFeed()),
+		"item removed":  feedETag("rss", unpublished),
+		"description":   feedETag("rss", described),
+		"item modified": feedETag("rss", edited),
+	} {
+		if tag == base {
+			t.Errorf("%s: etag did not change", name)
+		}
+	}
+}
+
+func TestFeedLastModified(t *testing.T) {
+	feed := testFeed()
+	if !feed.LastModified().Equal(feed.Updated) {
+		t.Errorf("LastModified = %v, want newest item %v", feed.LastModified(), feed.Updated)
+	}
+
+	// en yeni yazı yayından kalktı: Updated geriye gider ama Changed o anı taşır
+	unpublishedAt := feed.Updated.Add(time.Hour)
+	feed.Items = feed.Items[1:]
+	feed.Updated = feed.Items[0].Updated
+	feed.Changed = unpublishedAt
+	if !feed.LastModified().Equal(unpublishedAt) {
+		t.Errorf("LastModified = %v, want unpublish time %v", feed.LastModified(), unpublishedAt)
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	const etag = `W/"abc"`
+	lastModified := time.Date(2026, 1, 2, 10, 0, 0, 500, time.UTC)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{"no validators", nil, false},
+		{"matching etag", map[string]string{"If-None-Match": `W/"abc"`}, true},
+		{"strong form matches weak", map[string]string{"If-None-Match": `"abc"`}, true},
+		{"one of many", map[string]string{"If-None-Match": `"x", W/"abc"`}, true},
+		{"wildcard", map[string]string{"If-None-Match": "*"}, true},
+		{"other etag", map[string]string{"If-None-Match": `W/"def"`}, false},
+		{"empty entries", map[string]string{"If-None-Match": " , "}, false},
+		{"modified since exact", map[string]string{"If-Modified-Since": "Fri, 02 Jan 2026 10:00:00 GMT"}, true},
+		{"modified since later", map[string]string{"If-Modified-Since": "Sat, 03 Jan 2026 00:00:00 GMT"}, true},
+		{"modified since earlier", map[string]string{"If-Modified-Since": "Fri, 02 Jan 2026 09:59:59 GMT"}, false},
+		{"modified since invalid", map[string]string{"If-Modified-Since": "yesterday"}, false},
+		// If-None-Match varken If-Modified-Since dikkate alınmaz
+		{"etag mismatch wins", map[string]string{"If-None-Match": `W/"def"`, "If-Modified-Since": "Sat, 03 Jan 2026 00:00:00 GMT"}, false},
+		{"etag match wins", map[string]string{"If-None-Match": `W/"abc"`, "If-Modified-Since": "Thu, 01 Jan 2026 00:00:00 GMT"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			var got bool
+			app.Get("/", func(c *fiber.Ctx) error {
+				got = notModified(c, etag, lastModified)
+				return nil
+			})
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if _, err := app.Test(req); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("notModified = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeedRespondConditional(t *testing.T) {
+	h := &FeedHandler{}
+	app := fiber.New()
+	app.Get("/feeds", func(c *fiber.Ctx) error { return h.respond(c, testFeed(), nil) })
+
+	res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/feeds", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastModified := res.Header.Get(fiber.HeaderLastModified)
+	if res.StatusCode != fiber.StatusOK || lastModified != "Fri, 02 Jan 2026 10:00:00 GMT" {
+		t.Fatalf("status %d, Last-Modified %q", res.StatusCode, lastModified)
+	}
+
+	for name, header := range map[string][2]string{
+		"if-none-match":     {fiber.HeaderIfNoneMatch, res.Header.Get(fiber.HeaderETag)},
+		"if-modified-since": {fiber.HeaderIfModifiedSince, lastModified},
+	} {
+		req := httptest.NewRequest(fiber.MethodGet, "/feeds", nil)
+		req.Header.Set(header[0], header[1])
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.StatusCode != fiber.StatusNotModified {
+			t.Errorf("%s: status %d, want 304", name, res.StatusCode)
+		}
+	}
+}
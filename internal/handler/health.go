@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck, /readyz'de çalışan tek bir kontrol; nil dönmezse servis trafik almamalı
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	version       viewmodel.VersionVM
+	schemaVersion func(ctx context.Context) (int, error)
+	checks        []HealthCheck
+}
+
+// schemaVersion, DB'de uygulanmış migration versiyonunu verir; her /version isteğinde sorulur
+func NewHealthHandler(version viewmodel.VersionVM, schemaVersion func(ctx context.Context) (int, error), checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{version: version, schemaVersion: schemaVersion, checks: checks}
+}
+
+// Live, süreç cevap verebiliyorsa her zaman 200 döner; bağımlılıklar kontrol edilmez
+func (h *HealthHandler) Live(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(viewmodel.HealthVM{Status: "ok"})
+}
+
+// Ready, tüm kontroller geçerse 200, biri bile başarısızsa 503 döner
+func (h *HealthHandler) Ready(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), healthCheckTimeout)
+	defer cancel()
+
+	vm := viewmodel.HealthVM{Status: "ok", Checks: make(map[string]string, len(h.checks))}
+	status := fiber.StatusOK
+	for _, hc := range h.checks {
+		if err := hc.Check(ctx); err != nil {
+			slog.WarnContext(ctx, "readiness check failed", "check", hc.Name, "err", err)
+			vm.Checks[hc.Name] = "fail"
+			vm.Status = "unavailable"
+			status = fiber.StatusServiceUnavailable
+			continue
+		}
+		vm.Checks[hc.Name] = "ok"
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(status).JSON(vm)
+}
+
+func (h *HealthHandler) Version(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), healthCheckTimeout)
+	defer cancel()
+
+	current, err := h.schemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("schema version error: %w", err)
+	}
+	vm := h.version
+	vm.SchemaVersion = current
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(vm)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportHandler struct {
+	is service.ImportService
+}
+
+func NewImportHandler(is service.ImportService) *ImportHandler {
+	return &ImportHandler{is: is}
+}
+
+// ImportWXR, multipart "file" alanında WordPress export'u bekler (admin).
+// Çok büyük export'lar için "import-wxr" komutu kullanılmalı.
+func (h *ImportHandler) ImportWXR(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return service.ErrNotAllowed
+	}
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return service.Validation("file_required", "file required")
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return service.Validation("file_unreadable", "file read error").Wrap(err)
+	}
+	defer f.Close()
+
+	report, err := h.is.ImportWXR(c.UserContext(), f)
+	if err != nil {
+		// yarıda kalan import tekrar çalıştırılabilir; o ana kadar aktarılanlar raporda
+		p := viewmodel.NewProblem(fiber.StatusBadRequest, "import_failed", err.Error(), c.Path())
+		p.Data = report
+		return c.Status(p.Status).JSON(p, viewmodel.ProblemContentType)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": report})
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/service"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MediaHandler struct {
+	ms      service.MediaService
+	maxSize int64
+}
+
+func NewMediaHandler(ms service.MediaService, maxSize int64) *MediaHandler {
+	return &MediaHandler{ms: ms, maxSize: maxSize}
+}
+
+// Upload, multipart "file" alanını bekler
+func (h *MediaHandler) Upload(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return service.Validation("file_required", "file required")
+	}
+	if fh.Size > h.maxSize {
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, "file is too large")
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return service.Validation("file_unreadable", "file read error").Wrap(err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, h.maxSize+1))
+	if err != nil {
+		return service.Validation("file_unreadable", "file read error").Wrap(err)
+	}
+
+	vm, err := h.ms.Upload(c.UserContext(), username, fh.Filename, data)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": vm})
+}
+
+func (h *MediaHandler) List(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	list, err := h.ms.List(c.UserContext(), username)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": list})
+}
+
+func (h *MediaHandler) Delete(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	if err := h.ms.Delete(c.UserContext(), username, id); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "media_deleted")})
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+	"cleanArch_with_postgres/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// msg, "messages.<key>" mesajını isteğin dilinde döner. params ad/değer çiftleridir:
+// msg(c, "blogs_by_author", "author", username)
+func msg(c *fiber.Ctx, key string, params ...string) string {
+	var p map[string]string
+	if len(params) > 0 {
+		p = make(map[string]string, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			p[params[i]] = params[i+1]
+		}
+	}
+	return i18n.T(middleware.LocaleOf(c), "messages."+key, key, p)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bufio"
+	"cleanArch_with_postgres/internal/infrastructure/eventbus"
+	"cleanArch_with_postgres/internal/service"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const streamKeepAlive = 25 * time.Second
+
+type NotificationHandler struct {
+	ns       service.NotificationService
+	bus      eventbus.Bus
+	shutdown <-chan struct{}
+}
+
+// shutdown kapandığında açık SSE bağlantıları bırakılır ki server kapanışı beklemesin;
+// client retry ile başka bir instance'a bağlanır
+func NewNotificationHandler(ns service.NotificationService, bus eventbus.Bus, shutdown <-chan struct{}) *NotificationHandler {
+	return &NotificationHandler{ns: ns, bus: bus, shutdown: shutdown}
+}
+
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	username, _ := c.Locals("username").(string)
+	if username == "" {
+		return service.ErrInvalidToken
+	}
+	unread := c.Query("unread")
+	limit, _ := strconv.Atoi(c.Query("limit", "50"))
+
+	list, err := h.ns.List(c.UserContext(), username, unread == "true" || unread == "1", limit)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": list})
+}
+
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	username, _ := c.Locals("username").(string)
+	if username == "" {
+		return service.ErrInvalidToken
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	if err := h.ns.MarkRead(c.UserContext(), id, username); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "notification_read")})
+}
+
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	username, _ := c.Locals("username").(string)
+	if username == "" {
+		return service.ErrInvalidToken
+	}
+	if err := h.ns.MarkAllRead(c.UserContext(), username); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "notifications_all_read")})
+}
+
+// Stream, Server-Sent Events ile kullanıcıya ait event'leri (bildirim, onay bekleyen blog,
+// rol talebi) anlık olarak iletir. Adminlere özel event'ler sadece admin token'ına gider.
+func (h *NotificationHandler) Stream(c *fiber.Ctx) error {
+	username, _ := c.Locals("username").(string)
+	if username == "" {
+		return service.ErrInvalidToken
+	}
+	role, _ := c.Locals("role").(string)
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // nginx arkasında buffer'lamasın
+
+	events, cancel := h.bus.Subscribe(32)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		ticker := time.NewTicker(streamKeepAlive)
+		defer ticker.Stop()
+
+		fmt.Fprint(w, "retry: 5000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case e, ok := <-events:
+				if !ok { // bus kapandı
+					return
+				}
+				if !e.VisibleTo(username, role) {
+					continue
+				}
+				data, err := json.Marshal(e)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+			case <-h.shutdown:
+				return
+			case <-ticker.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			// client bağlantıyı kapattıysa flush hata döner
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
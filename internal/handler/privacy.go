@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PrivacyHandler struct {
+	ps service.PrivacyService
+}
+
+func NewPrivacyHandler(ps service.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{ps: ps}
+}
+
+// ExportData, kullanıcının kişisel verilerini JSON dosyası olarak indirir
+func (h *PrivacyHandler) ExportData(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	data, err := h.ps.ExportData(c.UserContext(), username)
+	if err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("%s-personal-data-%s.json", username, time.Now().Format("20060102"))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(data)
+}
+
+func (h *PrivacyHandler) GetErasure(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	req, err := h.ps.GetErasure(c.UserContext(), username)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": req})
+}
+
+// RequestErasure, body: {"password": "..."}
+func (h *PrivacyHandler) RequestErasure(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	var input viewmodel.ErasureConfirmVM
+	if err := c.BodyParser(&input); err != nil {
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
+	}
+
+	req, err := h.ps.RequestErasure(c.UserContext(), username, input.Password)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"data":    req,
+		"message": msg(c, "erasure_requested"),
+	})
+}
+
+func (h *PrivacyHandler) CancelErasure(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	if err := h.ps.CancelErasure(c.UserContext(), username); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "erasure_cancelled")})
+}
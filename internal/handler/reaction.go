@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReactionHandler struct {
+	rs service.ReactionService
+}
+
+func NewReactionHandler(rs service.ReactionService) *ReactionHandler {
+	return &ReactionHandler{rs: rs}
+}
+
+func (h *ReactionHandler) ListTypes(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": h.rs.Types()})
+}
+
+func (h *ReactionHandler) React(c *fiber.Ctx) error {
+	title := c.Params("title")
+	if title == "" {
+		return service.ErrInvalidTitle
+	}
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+
+	var input viewmodel.ReactionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&input); err != nil {
+			return service.ErrInvalidInput.Wrap(err)
+		}
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
+	}
+
+	resp, err := h.rs.React(c.UserContext(), title, username, input.Type)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp})
+}
+
+func (h *ReactionHandler) Unreact(c *fiber.Ctx) error {
+	title := c.Params("title")
+	if title == "" {
+		return service.ErrInvalidTitle
+	}
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+
+	resp, err := h.rs.Unreact(c.UserContext(), title, username, c.Params("type"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp})
+}
+
+func (h *ReactionHandler) ListLiked(c *fiber.Ctx) error {
+	username, ok := c.Locals("username").(string)
+	if !ok || username == "" {
+		return service.ErrInvalidToken
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "50"))
+
+	resp, err := h.rs.ListLiked(c.UserContext(), username, limit)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": resp})
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RetentionHandler struct {
+	rs service.RetentionService
+}
+
+func NewRetentionHandler(rs service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{rs: rs}
+}
+
+// Preview, sıradaki purge turunda kalıcı olarak silinecek kayıtları döner, hiçbir şey silmez (admin)
+func (h *RetentionHandler) Preview(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return service.ErrNotAllowed
+	}
+	report, err := h.rs.Preview(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": report})
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SitemapHandler struct {
+	ss service.SitemapService
+}
+
+func NewSitemapHandler(ss service.SitemapService) *SitemapHandler {
+	return &SitemapHandler{ss: ss}
+}
+
+func (h *SitemapHandler) Sitemap(c *fiber.Ctx) error {
+	vm, err := h.ss.Sitemap(c.UserContext())
+	return h.respond(c, vm, err)
+}
+
+func (h *SitemapHandler) SitemapPage(c *fiber.Ctx) error {
+	page, err := c.ParamsInt("page")
+	if err != nil {
+		return service.ErrSitemapPageNotFound
+	}
+	vm, err := h.ss.SitemapPage(c.UserContext(), page)
+	return h.respond(c, vm, err)
+}
+
+func (h *SitemapHandler) respond(c *fiber.Ctx, vm *viewmodel.SitemapVM, err error) error {
+	if err != nil {
+		return err
+	}
+	body, err := vm.XML()
+	if err != nil {
+		return fmt.Errorf("sitemap render error: %w", err)
+	}
+	c.Set(fiber.HeaderContentType, "application/xml; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "public, max-age=3600")
+	return c.Status(fiber.StatusOK).Send(body)
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Webhook endpointleri sadece adminler içindir
+type WebhookHandler struct {
+	ws service.WebhookService
+}
+
+func NewWebhookHandler(ws service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{ws: ws}
+}
+
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return service.ErrNotAllowed
+	}
+	list, err := h.ws.ListWebhooks(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": list})
+}
+
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	role, _ := c.Locals("role").(string)
+	admin, _ := c.Locals("username").(string)
+	if role != "admin" {
+		return service.ErrNotAllowed
+	}
+
+	var input viewmodel.WebhookCreateVM
+	if err := c.BodyParser(&input); err != nil {
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
+	}
+
+	vm, err := h.ws.CreateWebhook(c.UserContext(), admin, &input)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": vm, "message": msg(c, "webhook_created")})
+}
+
+func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return service.ErrNotAllowed
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	vm, err := h.ws.GetWebhook(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": vm})
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return service.ErrNotAllowed
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+
+	var input viewmodel.WebhookUpdateVM
+	if err := c.BodyParser(&input); err != nil {
+		return service.ErrInvalidInput.Wrap(err)
+	}
+	if err := service.Validate(&input); err != nil {
+		return err
+	}
+
+	vm, err := h.ws.UpdateWebhook(c.UserContext(), id, &input)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": vm, "message": msg(c, "webhook_updated")})
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return service.ErrNotAllowed
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	if err := h.ws.DeleteWebhook(c.UserContext(), id); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": msg(c, "webhook_deleted")})
+}
+
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return service.ErrNotAllowed
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "100"))
+
+	list, err := h.ws.ListDeliveries(c.UserContext(), id, c.Query("status"), limit)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": list})
+}
+
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return service.ErrNotAllowed
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	vm, err := h.ws.GetDelivery(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"data": vm})
+}
+
+func (h *WebhookHandler) ReplayDelivery(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		return service.ErrNotAllowed
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		return service.ErrInvalidID
+	}
+	vm, err := h.ws.ReplayDelivery(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"data": vm, "message": msg(c, "delivery_requeued")})
+}
+
+func paramID(c *fiber.Ctx, name string) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Params(name), 10, 64)
+	if err != nil || id64 == 0 {
+		return 0, false
+	}
+	return uint(id64), true
+}
@@ -3,12 +3,25 @@ package app
 import (
 	"cleanArch_with_postgres/internal/infrastructure/config"
 	"cleanArch_with_postgres/internal/infrastructure/database"
+	"cleanArch_with_postgres/internal/infrastructure/eventbus"
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+	"cleanArch_with_postgres/internal/infrastructure/logger"
+	"cleanArch_with_postgres/internal/infrastructure/metrics"
+	"cleanArch_with_postgres/internal/infrastructure/storage"
+	"cleanArch_with_postgres/internal/infrastructure/tracing"
+	"cleanArch_with_postgres/internal/infrastructure/worker"
+	"cleanArch_with_postgres/internal/middleware"
+	"context"
+	"errors"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"gorm.io/gorm"
 )
@@ -17,48 +30,177 @@ type App struct {
 	FiberApp *fiber.App
 	DB       *gorm.DB
 	Cfg      *config.Config
+	Bus      eventbus.Bus
+	Storage  storage.Storage
+	Workers  *worker.Runner
+	Admin    *http.Server // metrics.addr verildiyse /metrics'i sunan ayrı server
+
+	hooks    []Hook
+	done     chan struct{}
+	failed   chan error
+	draining atomic.Bool
 }
 
 type IRouter interface {
-	RegisterRouter(app *App)
+	RegisterRouter(app *App) error
 }
 
-func New(router IRouter) *App {
+// New, bileşenleri kurar; hata olursa o ana kadar açılanlar (tracing, DB, event bus) kapatılıp
+// hata döner, çıkış kodunu main belirler
+func New(router IRouter) (_ *App, err error) {
 	cfg, err := config.Setup()
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	if _, err := logger.Setup(cfg.Log); err != nil {
+		return nil, fmt.Errorf("logger: %w", err)
+	}
+	shutdownTracing, err := tracing.Setup(cfg.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
 	}
 
-	fiberApp := fiber.New()
-	db := database.New(cfg.Database)
+	app := &App{
+		Cfg:     cfg,
+		Workers: worker.NewRunner(),
 
-	fiberApp.Use(cors.New(cors.Config{
-		AllowOrigins: "http://localhost:5173", // http://localhost:5173	http://---IP---:5173
-		AllowMethods: "GET,POST,PUT,PATCH,DELETE,OPTIONS",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-	}))
+		done:   make(chan struct{}),
+		failed: make(chan error, 1),
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if stopErr := app.stopHooks(len(app.hooks), false); stopErr != nil {
+			slog.Error("shutdown failed", "err", stopErr)
+		}
+	}()
 
-	app := &App{
-		FiberApp: fiberApp,
-		DB:       db,
-		Cfg:      cfg,
+	// Stop'lar ters sırada: önce HTTP yeni istek almayı bırakır ve açık istekler biter, sonra işler,
+	// event bus ve DB kapanır; tracing en son ki kapanıştaki span'ler de gönderilsin
+	app.OnLifecycle(Hook{Name: "tracing", Stop: shutdownTracing})
+
+	if err := i18n.Load(cfg.I18n.Dir, cfg.I18n.DefaultLocale); err != nil {
+		return nil, fmt.Errorf("i18n: %w", err)
 	}
 
-	router.RegisterRouter(app)
+	db, err := database.Open(cfg.Database, cfg.Log)
+	if err != nil {
+		return nil, fmt.Errorf("database: %w", err)
+	}
+	app.DB = db
+	app.OnLifecycle(Hook{Name: "database", Stop: func(context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	}})
+	if err := metrics.RegisterDB(db, cfg.Database.Name); err != nil {
+		return nil, fmt.Errorf("metrics: %w", err)
+	}
+	if err := tracing.RegisterDB(db); err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
+	}
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return nil, fmt.Errorf("migrations: %w", err)
+	}
+	if err := database.EnsureSchema(context.Background(), migrator, cfg.Database.AutoMigrate); err != nil {
+		return nil, fmt.Errorf("database schema check: %w", err)
+	}
 
-	return app
+	bus, err := eventbus.New(cfg.Events, db, database.DSN(cfg.Database))
+	if err != nil {
+		return nil, fmt.Errorf("eventbus: %w", err)
+	}
+	app.Bus = bus
+	app.OnLifecycle(Hook{Name: "eventbus", Stop: func(context.Context) error { return bus.Close() }})
+
+	store, err := storage.New(cfg.Media)
+	if err != nil {
+		return nil, fmt.Errorf("storage: %w", err)
+	}
+	app.Storage = store
+
+	app.FiberApp = fiber.New(fiber.Config{
+		BodyLimit:    int(cfg.Media.MaxSize) + 1<<20, // medya yüklemeleri + multipart ek yükü
+		ErrorHandler: middleware.ErrorHandler,
+	})
+	app.FiberApp.Use(middleware.Tracing())
+	app.FiberApp.Use(middleware.RequestID())
+	app.FiberApp.Use(middleware.Metrics())
+	app.FiberApp.Use(middleware.AccessLog())
+	app.FiberApp.Use(cors.New(cors.Config{
+		AllowOrigins:  "http://localhost:5173", // http://localhost:5173	http://---IP---:5173
+		AllowMethods:  "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:  "Origin, Content-Type, Accept, Accept-Language, Authorization, X-Request-ID, Traceparent, Tracestate",
+		ExposeHeaders: "X-Request-ID",
+	}))
+	app.FiberApp.Use(middleware.Locale())
+
+	app.OnLifecycle(Hook{
+		Name:  "workers",
+		Start: func(context.Context) error { app.Workers.Start(); return nil },
+		Stop:  func(ctx context.Context) error { return waitCtx(ctx, app.Workers.Stop) },
+	})
+
+	if err := router.RegisterRouter(app); err != nil {
+		return nil, fmt.Errorf("router: %w", err)
+	}
+	app.mountMetrics()
+	app.OnLifecycle(Hook{Name: "http", Start: app.startHTTP, Stop: app.FiberApp.ShutdownWithContext})
+
+	return app, nil
 }
 
-func (a *App) Start() {
+// startHTTP, port'u burada açar ki port doluysa hata Start'tan dönsün; sonrasında server'ın
+// durması fail ile bildirilir
+func (a *App) startHTTP(context.Context) error {
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%v", a.Cfg.Server.Port))
+	if err != nil {
+		return err
+	}
 	go func() {
-		err := a.FiberApp.Listen(fmt.Sprintf(":%v", a.Cfg.Server.Port))
-		if err != nil {
-			panic(err)
+		if err := a.FiberApp.Listener(ln); err != nil {
+			a.fail("http", err)
 		}
 	}()
+	return nil
+}
+
+// mountMetrics, /metrics'i ayrı admin port'a ya da token korumalı olarak ana port'a bağlar.
+// Ana port'taki /metrics API'nin parçası olmadığı için OpenAPI dokümanında yer almaz.
+func (a *App) mountMetrics() {
+	m := a.Cfg.Metrics
+	switch {
+	case m.Addr != "":
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		a.Admin = &http.Server{Addr: m.Addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+		a.OnLifecycle(Hook{Name: "admin", Start: a.startAdmin, Stop: a.Admin.Shutdown})
+	case m.Token != "":
+		a.FiberApp.Get("/metrics", middleware.MetricsAuth(m.Token), adaptor.HTTPHandler(metrics.Handler()))
+	default:
+		slog.Warn("metrics endpoint disabled, set metrics.addr or metrics.token")
+	}
+}
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+// Draining, kapanma sinyali alındıysa true döner; /readyz bu durumda 503 verir ki
+// load balancer yeni istek göndermeyi bıraksın
+func (a *App) Draining() bool {
+	return a.draining.Load()
+}
 
-	<-c
+func (a *App) startAdmin(context.Context) error {
+	ln, err := net.Listen("tcp", a.Admin.Addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := a.Admin.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.fail("admin", err)
+		}
+	}()
+	return nil
 }
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Çıkış kodları; orchestrator/systemd neden kapandığını buradan ayırt eder
+const (
+	ExitOK             = 0 // sinyalle düzgün kapandı
+	ExitStartFailed    = 1 // bir bileşen başlatılamadı
+	ExitServerFailed   = 3 // çalışırken bir bileşen (HTTP server vb.) durdu
+	ExitShutdownFailed = 4 // kapanış timeout'a uğradı ya da bir stop hook hata verdi
+)
+
+// Hook, bir bileşenin açılış ve kapanış adımları. Start'lar kayıt sırasıyla, Stop'lar ters sırayla
+// çağrılır; ikisi de boş olabilir. Start bloklamamalı, uzun süren işi goroutine'de başlatmalı.
+type Hook struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// OnLifecycle, Start'tan önce çağrılmalı
+func (a *App) OnLifecycle(h Hook) {
+	a.hooks = append(a.hooks, h)
+}
+
+// Done, kapanış başladığında kapanır; uzun süren istekler (SSE) bunu dinleyip bağlantıyı bırakır
+func (a *App) Done() <-chan struct{} {
+	return a.done
+}
+
+// fail, çalışan bir bileşenin kalıcı hatasını bildirir; süreç kapanışa geçer
+func (a *App) fail(name string, err error) {
+	select {
+	case a.failed <- fmt.Errorf("%s: %w", name, err):
+	default: // kapanış zaten başladı
+	}
+}
+
+// Start, bileşenleri başlatır, sinyal ya da bir bileşenin hatasıyla kapanır ve çıkış kodunu döner
+func (a *App) Start() int {
+	started, err := a.startHooks()
+	if err != nil {
+		slog.Error("startup failed", "err", err)
+		if err := a.stopHooks(started, false); err != nil {
+			slog.Error("shutdown failed", "err", err)
+		}
+		return ExitStartFailed
+	}
+	slog.Info("server started", "port", a.Cfg.Server.Port)
+
+	sig := make(chan os.Signal, 2)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	code := ExitOK
+	select {
+	case s := <-sig:
+		slog.Info("shutdown signal received", "signal", s.String())
+	case err := <-a.failed:
+		slog.Error("component failed, shutting down", "err", err)
+		code = ExitServerFailed
+	}
+
+	// ikinci sinyalde beklemeden çıkılır
+	go func() {
+		<-sig
+		slog.Warn("second signal received, exiting immediately")
+		os.Exit(ExitShutdownFailed)
+	}()
+
+	if err := a.stopHooks(started, true); err != nil {
+		slog.Error("shutdown failed", "err", err)
+		if code == ExitOK {
+			code = ExitShutdownFailed
+		}
+	}
+	slog.Info("shutdown complete", "exit_code", code)
+	return code
+}
+
+func (a *App) startHooks() (int, error) {
+	ctx := context.Background()
+	for i, h := range a.hooks {
+		if h.Start == nil {
+			continue
+		}
+		if err := h.Start(ctx); err != nil {
+			return i, fmt.Errorf("%s: %w", h.Name, err)
+		}
+	}
+	return len(a.hooks), nil
+}
+
+// stopHooks, ilk n hook'u ters sırayla durdurur. Önce /readyz 503'e döner ve drain ise DrainDelay
+// kadar beklenir; toplam süre ShutdownTimeout'u aşarsa kalan hook'lar iptal edilmiş context'le çağrılır.
+func (a *App) stopHooks(n int, drain bool) error {
+	a.draining.Store(true)
+	close(a.done)
+	if d := a.Cfg.Server.DrainDelay; drain && d > 0 {
+		slog.Info("draining", "delay", d)
+		time.Sleep(d)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.Cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := n - 1; i >= 0; i-- {
+		h := a.hooks[i]
+		if h.Stop == nil {
+			continue
+		}
+		start := time.Now()
+		if err := h.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.Name, err))
+			continue
+		}
+		slog.Debug("component stopped", "component", h.Name, "took", time.Since(start))
+	}
+	return errors.Join(errs...)
+}
+
+// waitCtx, bloklayan bir durdurma fonksiyonunu context süresiyle sınırlar
+func waitCtx(ctx context.Context, stop func()) error {
+	done := make(chan struct{})
+	go func() {
+		stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestApp(timeout time.Duration) *App {
+	return &App{
+		Cfg:    &config.Config{Server: config.ServerConfig{ShutdownTimeout: timeout}},
+		done:   make(chan struct{}),
+		failed: make(chan error, 1),
+	}
+}
+
+func TestLifecycleOrder(t *testing.T) {
+	var calls []string
+	hook := func(name string, startErr error) Hook {
+		return Hook{
+			Name: name,
+			Start: func(context.Context) error {
+				calls = append(calls, "start "+name)
+				return startErr
+			},
+			Stop: func(context.Context) error {
+				calls = append(calls, "stop "+name)
+				return nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		hooks []Hook
+		want  []string
+	}{
+		{"all started, stopped in reverse", []Hook{hook("db", nil), hook("workers", nil), hook("http", nil)},
+			[]string{"start db", "start workers", "start http", "stop http", "stop workers", "stop db"}},
+		// başlatılamayan hook ve sonrakiler durdurulmaz
+		{"start failure stops only started hooks", []Hook{hook("db", nil), hook("workers", errors.New("boom")), hook("http", nil)},
+			[]string{"start db", "start workers", "stop db"}},
+		{"nil start and stop are skipped", []Hook{hook("db", nil), {Name: "noop"}, hook("http", nil)},
+			[]string{"start db", "start http", "stop http", "stop db"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			a := newTestApp(time.Second)
+			for _, h := range tt.hooks {
+				a.OnLifecycle(h)
+			}
+			n, _ := a.startHooks()
+			if err := a.stopHooks(n, false); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(calls, tt.want) {
+				t.Errorf("calls = %v, want %v", calls, tt.want)
+			}
+			if !a.Draining() {
+				t.Error("app not draining after stop")
+			}
+			select {
+			case <-a.Done():
+			default:
+				t.Error("Done not closed after stop")
+			}
+		})
+	}
+}
+
+func TestStopHooksErrors(t *testing.T) {
+	a := newTestApp(20 * time.Millisecond)
+	var stopped []string
+	a.OnLifecycle(Hook{Name: "db", Stop: func(context.Context) error {
+		stopped = append(stopped, "db")
+		return nil
+	}})
+	a.OnLifecycle(Hook{Name: "slow", Stop: func(ctx context.Context) error {
+		return waitCtx(ctx, func() { time.Sleep(time.Second) })
+	}})
+	a.OnLifecycle(Hook{Name: "broken", Stop: func(context.Context) error { return errors.New("boom") }})
+
+	err := a.stopHooks(3, false)
+	// bir hook'un hatası sonrakileri durdurmaz, hepsi tek hatada toplanır
+	if !reflect.DeepEqual(stopped, []string{"db"}) {
+		t.Errorf("stopped = %v, want [db]", stopped)
+	}
+	if err == nil || !strings.Contains(err.Error(), "broken: boom") || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want broken and slow errors", err)
+	}
+}
@@ -0,0 +1,48 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Build sırasında -ldflags ile doldurulur:
+//
+//	go build -ldflags "-X cleanArch_with_postgres/internal/infrastructure/buildinfo.Commit=$(git rev-parse HEAD) \
+//		-X cleanArch_with_postgres/internal/infrastructure/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd
+//
+// Boş kalırlarsa Go'nun binary'ye gömdüğü VCS bilgisi kullanılır.
+var (
+	Commit    string
+	BuildTime string
+)
+
+type Info struct {
+	Commit    string
+	BuildTime string
+	GoVersion string
+	Modified  bool // commit'lenmemiş değişikliklerle derlendi
+}
+
+func Get() Info {
+	info := Info{Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = s.Value
+				}
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	return info
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -11,9 +13,22 @@ import (
 var config *Config
 
 type Config struct {
-	Database DBConfig
-	Server   ServerConfig
-	Secret   JWTConfig
+	Database  DBConfig
+	Server    ServerConfig
+	Site      SiteConfig
+	Secret    JWTConfig
+	Events    EventsConfig
+	Webhook   WebhookConfig
+	Reaction  ReactionConfig
+	Analytics AnalyticsConfig
+	Trending  TrendingConfig
+	Media     MediaConfig
+	Privacy   PrivacyConfig
+	Retention RetentionConfig
+	I18n      I18nConfig
+	Log       LogConfig
+	Metrics   MetricsConfig
+	Tracing   TracingConfig
 }
 
 type DBConfig struct {
@@ -22,16 +37,129 @@ type DBConfig struct {
 	Password string
 	Host     string
 	Port     string
+	// şema geride ise açılışta bekleyen migration'lar uygulanır; kapalıysa sunucu başlamaz
+	AutoMigrate bool
 }
 
 type ServerConfig struct {
 	Port string
+	// kapanışta /readyz 503 döndükten sonra listener kapanmadan önce beklenen süre; load balancer'ın
+	// instance'ı rotasyondan çıkarmasına zaman tanır
+	DrainDelay time.Duration
+	// açık isteklerin bitmesi ve bileşenlerin durması için toplam süre, aşılırsa süreç hata koduyla çıkar
+	ShutdownTimeout time.Duration
+}
+
+// SiteConfig, feed ve sitemap gibi dışarıya link veren çıktılarda kullanılır
+type SiteConfig struct {
+	Title       string
+	Description string
+	BaseURL     string // frontend adresi, sonunda / olmadan
+	FeedLimit   int
 }
 
 type JWTConfig struct {
 	JWTSecret string
 }
 
+type WebhookConfig struct {
+	MaxAttempts  int
+	Timeout      time.Duration // tek bir HTTP isteği için
+	PollInterval time.Duration // bekleyen teslimatların kontrol aralığı
+	BaseBackoff  time.Duration // 1. denemeden sonra bekleme, her denemede ikiye katlanır
+	MaxBackoff   time.Duration
+}
+
+type ReactionConfig struct {
+	Types []string // "like" her zaman geçerlidir, buradakiler ek emoji tepkileri
+}
+
+type AnalyticsConfig struct {
+	ViewWindow time.Duration // aynı okuyucunun tekrar sayılmadığı zaman dilimi
+}
+
+type TrendingConfig struct {
+	Interval       time.Duration // skorların yeniden hesaplanma aralığı
+	ViewWeight     float64
+	ReactionWeight float64
+	CommentWeight  float64
+}
+
+type MediaConfig struct {
+	Driver     string        // "local" veya "s3" (MinIO gibi S3 uyumlu servisler dahil)
+	LocalDir   string        // local driver için dosyaların yazıldığı klasör
+	PublicURL  string        // dosyaların dışarıdan erişilen adresi; boşsa local için sunucu, s3 için endpoint/bucket
+	MaxSize    int64         // tek dosya için byte sınırı
+	UserQuota  int64         // kullanıcı başına toplam byte sınırı
+	ThumbWidth int           // küçük resim genişliği (px)
+	MaxPixels  int           // genişlik*yükseklik sınırı; decode edilen görsel bellekte ~4 byte/piksel yer kaplar
+	GCInterval time.Duration // referanssız medya temizliği aralığı
+	GCGrace    time.Duration // yeni yüklenen medya bu süre dolmadan silinmez
+	S3         S3Config
+}
+
+type S3Config struct {
+	Endpoint  string // host:port, örn. localhost:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+}
+
+type PrivacyConfig struct {
+	ErasureGrace    time.Duration // talep ile anonimleştirme arasındaki süre, bu sürede iptal edilebilir
+	ErasurePolicy   string        // "reassign" (yazılar placeholder kullanıcıya geçer) veya "delete"
+	PlaceholderUser string        // reassign politikasında yazıların aktarıldığı kullanıcı adı
+	ErasureInterval time.Duration // vadesi gelen taleplerin kontrol aralığı
+}
+
+// RetentionConfig, soft-delete edilen kayıtların kalıcı silinmeden önce tutulduğu süreler.
+// 0 verilen tür hiç silinmez.
+type RetentionConfig struct {
+	Users         time.Duration
+	Blogs         time.Duration
+	Comments      time.Duration
+	Notifications time.Duration
+	Interval      time.Duration // purge işinin çalışma aralığı
+	BatchSize     int           // tek turda silinecek en fazla kullanıcı/blog/yorum/bildirim sayısı
+}
+
+// I18nConfig, API mesajlarının dili. Kataloglar gömülü gelir; Dir verilirse oradaki <dil>.json
+// dosyaları eklenir (yeni dil ya da mevcut mesajları ezmek için).
+type I18nConfig struct {
+	DefaultLocale string
+	Dir           string
+}
+
+// LogConfig, slog ayarları. SQL sorguları parametresiz loglanır, değerler (şifre hash'i vb.) yazılmaz.
+type LogConfig struct {
+	Level     string        // debug, info, warn, error
+	Format    string        // "text" veya "json"
+	SlowQuery time.Duration // bu süreyi aşan SQL sorguları warn olarak loglanır
+}
+
+// MetricsConfig, Prometheus /metrics endpoint'i. Addr verilirse ayrı admin port'tan sunulur;
+// boşsa ana port'tan sadece Token ile erişilir, ikisi de boşsa kapalıdır.
+type MetricsConfig struct {
+	Addr  string // örn. ":9091"
+	Token string
+}
+
+// TracingConfig, OpenTelemetry izleri. Exporter "none" iken span'ler üretilmez (no-op),
+// "stdout" yerel hata ayıklama için span'leri stdout'a yazar, "otlp" Endpoint'e OTLP/HTTP ile gönderir.
+type TracingConfig struct {
+	Exporter    string  // none, stdout, otlp
+	Endpoint    string  // otlp için host:port, örn. "localhost:4318"
+	Insecure    bool    // otlp bağlantısı TLS'siz
+	SampleRatio float64 // 0-1 arası; gelen isteğin parent kararı her zaman korunur
+	ServiceName string
+}
+
+type EventsConfig struct {
+	Driver string // "memory" (tek instance) veya "postgres" (LISTEN/NOTIFY ile instance'lar arası)
+}
+
 func setDefaults() {
 
 	viper.SetDefault("database.name", "cleanarch_blog")
@@ -39,11 +167,80 @@ func setDefaults() {
 	viper.SetDefault("database.password", "157595355")
 	viper.SetDefault("database.host", "localhost")
 	viper.SetDefault("database.port", "5432")
+	viper.SetDefault("database.automigrate", false)
 
 	viper.SetDefault("server.port", "3000") // hata: port string olması gerekirken integer değer girmişim
+	viper.SetDefault("server.draindelay", "0s")
+	viper.SetDefault("server.shutdowntimeout", "15s")
 
 	viper.SetDefault("secret.jwtsecret", "mcordal123")
 
+	viper.SetDefault("site.title", "LogNode Blog")
+	viper.SetDefault("site.description", "Go, backend ve yazılım üzerine yazılar")
+	viper.SetDefault("site.baseurl", "http://localhost:5173")
+	viper.SetDefault("site.feedlimit", 50)
+
+	viper.SetDefault("events.driver", "memory")
+
+	viper.SetDefault("webhook.maxattempts", 8)
+	viper.SetDefault("webhook.timeout", "10s")
+	viper.SetDefault("webhook.pollinterval", "5s")
+	viper.SetDefault("webhook.basebackoff", "30s")
+	viper.SetDefault("webhook.maxbackoff", "6h")
+
+	viper.SetDefault("reaction.types", []string{"like", "love", "laugh", "wow", "sad", "fire"})
+
+	viper.SetDefault("analytics.viewwindow", "30m")
+
+	viper.SetDefault("trending.interval", "5m")
+	viper.SetDefault("trending.viewweight", 1.0)
+	viper.SetDefault("trending.reactionweight", 3.0)
+	viper.SetDefault("trending.commentweight", 5.0)
+
+	viper.SetDefault("media.driver", "local")
+	viper.SetDefault("media.localdir", "./uploads")
+	viper.SetDefault("media.publicurl", "")
+	viper.SetDefault("media.maxsize", 10<<20)    // 10 MB
+	viper.SetDefault("media.userquota", 200<<20) // 200 MB
+	viper.SetDefault("media.thumbwidth", 480)
+	viper.SetDefault("media.maxpixels", 40_000_000) // ~160 MB decode
+	viper.SetDefault("media.gcinterval", "1h")
+	viper.SetDefault("media.gcgrace", "24h")
+	viper.SetDefault("media.s3.endpoint", "localhost:9000")
+	viper.SetDefault("media.s3.region", "us-east-1")
+	viper.SetDefault("media.s3.bucket", "blog-media")
+	viper.SetDefault("media.s3.accesskey", "minioadmin")
+	viper.SetDefault("media.s3.secretkey", "minioadmin")
+	viper.SetDefault("media.s3.usessl", false)
+
+	viper.SetDefault("privacy.erasuregrace", "720h") // 30 gün
+	viper.SetDefault("privacy.erasurepolicy", "reassign")
+	viper.SetDefault("privacy.placeholderuser", "deleted-user")
+	viper.SetDefault("privacy.erasureinterval", "1h")
+
+	viper.SetDefault("retention.users", "2160h") // 90 gün
+	viper.SetDefault("retention.blogs", "2160h")
+	viper.SetDefault("retention.comments", "720h") // 30 gün
+	viper.SetDefault("retention.notifications", "720h")
+	viper.SetDefault("retention.interval", "6h")
+	viper.SetDefault("retention.batchsize", 200)
+
+	viper.SetDefault("i18n.defaultlocale", "tr")
+	viper.SetDefault("i18n.dir", "")
+
+	viper.SetDefault("log.level", "info")
+	viper.SetDefault("log.format", "text")
+	viper.SetDefault("log.slowquery", "200ms")
+
+	viper.SetDefault("metrics.addr", ":9091")
+	viper.SetDefault("metrics.token", "")
+
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.endpoint", "localhost:4318")
+	viper.SetDefault("tracing.insecure", true)
+	viper.SetDefault("tracing.sampleratio", 1.0)
+	viper.SetDefault("tracing.servicename", "cleanarch-blog")
+
 }
 
 func Setup() (*Config, error) {
@@ -75,6 +272,16 @@ func Setup() (*Config, error) {
 		}
 	}
 
+	if config.Media.PublicURL == "" && (config.Media.Driver == "" || config.Media.Driver == "local") {
+		config.Media.PublicURL = fmt.Sprintf("http://localhost:%s/media/files", config.Server.Port)
+	}
+
+	switch config.Privacy.ErasurePolicy {
+	case "reassign", "delete":
+	default:
+		return nil, fmt.Errorf("invalid privacy.erasurepolicy %q, expected reassign|delete", config.Privacy.ErasurePolicy)
+	}
+
 	// JWT Secret için yedek bir değer ayarlar, eğer env'de yoksa default kullanır
 	if config.Secret.JWTSecret == "" {
 		config.Secret.JWTSecret = os.Getenv("JWT_SECRET")
@@ -1,21 +1,295 @@
 package database
 
 import (
-	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 )
 
-func AutoMigrate(db *gorm.DB) {
-	migrate(db, &entity.User{})
-	migrate(db, &entity.Blog{})
-	migrate(db, &entity.RoleRequest{})
+// Migration dosyaları: migrations/0002_add_x.up.sql + migrations/0002_add_x.down.sql. Versiyonlar
+// artan tam sayılardır, uygulanmış bir dosya değiştirilmez, değişiklik için yeni versiyon eklenir.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrationName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Aynı anda açılan instance'ların migration'ı iki kez uygulamaması için pg_advisory_lock anahtarı
+const migrationLockKey = 727_001
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus, bir migration'ın veritabanındaki durumu
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time // nil ise uygulanmamış
+}
+
+// schemaMigration, uygulanan migration'ların kaydı
+type schemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string { return "schema_migrations" }
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration // versiyona göre sıralı
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	for _, name := range entries {
+		m := migrationName.FindStringSubmatch(path.Base(name))
+		if m == nil {
+			return nil, fmt.Errorf("migration: invalid file name %q, expected 0001_name.up.sql", name)
+		}
+		version, _ := strconv.Atoi(m[1])
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration: version %d used by %q and %q", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration: %04d_%s needs both up and down files", mig.Version, mig.Name)
+		}
+		out = append(out, *mig)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// Latest, bu binary'nin beklediği şema versiyonu
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Current, veritabanında uygulanmış en yüksek versiyon; hiç migration yoksa 0
+func (m *Migrator) Current(ctx context.Context) (int, error) {
+	db := m.db.WithContext(ctx)
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return 0, nil
+	}
+	var version int
+	err := db.Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied := map[int]time.Time{}
+	db := m.db.WithContext(ctx)
+	if db.Migrator().HasTable(&schemaMigration{}) {
+		var rows []schemaMigration
+		if err := db.Order("version").Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			applied[r.Version] = r.AppliedAt
+		}
+	}
+
+	out := make([]MigrationStatus, len(m.migrations))
+	for i, mig := range m.migrations {
+		out[i] = MigrationStatus{Version: mig.Version, Name: mig.Name}
+		if at, ok := applied[mig.Version]; ok {
+			out[i].AppliedAt = &at
+		}
+	}
+	return out, nil
+}
+
+// Up, bekleyen tüm migration'ları uygular
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	return m.To(ctx, m.Latest())
 }
 
-func migrate(db *gorm.DB, model interface{}) {
-	err := db.AutoMigrate(model)
+// Down, son uygulanan migration'ı geri alır
+func (m *Migrator) Down(ctx context.Context) ([]Migration, error) {
+	current, err := m.Current(ctx)
 	if err != nil {
-		fmt.Println(err)
+		return nil, err
+	}
+	if current == 0 {
+		return nil, nil
+	}
+	return m.To(ctx, m.Previous(current))
+}
+
+// Previous, version'dan önceki migration'ın versiyonu; ilk migration için 0 (tüm şema silinir)
+func (m *Migrator) Previous(version int) int {
+	prev := 0
+	for _, mig := range m.migrations {
+		if mig.Version < version {
+			prev = mig.Version
+		}
+	}
+	return prev
+}
+
+// To, şemayı verilen versiyona getirir: gerekirse ileri, gerekirse geri. Her migration kendi
+// transaction'ında schema_migrations kaydıyla birlikte uygulanır; hata olursa o adım geri alınır
+// ve önceki adımlar kalır. Dönen liste uygulanan (geri alınan) migration'lar.
+func (m *Migrator) To(ctx context.Context, target int) ([]Migration, error) {
+	if target != 0 && m.find(target) == nil {
+		return nil, fmt.Errorf("migration: unknown version %d", target)
+	}
+
+	var done []Migration
+	err := m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		// lock bağlantıya bağlı olduğu için tüm adımlar aynı bağlantıda çalışır
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+
+		if err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint PRIMARY KEY,
+			name text NOT NULL,
+			applied_at timestamptz NOT NULL
+		)`).Error; err != nil {
+			return err
+		}
+		// lock alınana kadar başka bir instance migration'ı bitirmiş olabilir, tekrar okunur
+		current, err := (&Migrator{db: conn, migrations: m.migrations}).Current(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.plan(current, target) {
+			up := mig.Version > current
+			if err := conn.Transaction(func(tx *gorm.DB) error {
+				if up {
+					if err := tx.Exec(mig.Up).Error; err != nil {
+						return err
+					}
+					return tx.Create(&schemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+				}
+				if err := tx.Exec(mig.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, mig.Version).Error
+			}); err != nil {
+				direction := "up"
+				if !up {
+					direction = "down"
+				}
+				return fmt.Errorf("migration %04d_%s %s: %w", mig.Version, mig.Name, direction, err)
+			}
+			slog.InfoContext(ctx, "migration applied", "version", mig.Version, "name", mig.Name, "up", up)
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// plan, current'tan target'a giderken çalışacak migration'lar; geri giderken büyükten küçüğe
+func (m *Migrator) plan(current, target int) []Migration {
+	var out []Migration
+	if target >= current {
+		for _, mig := range m.migrations {
+			if mig.Version > current && mig.Version <= target {
+				out = append(out, mig)
+			}
+		}
+		return out
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if mig := m.migrations[i]; mig.Version <= current && mig.Version > target {
+			out = append(out, mig)
+		}
+	}
+	return out
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// ErrSchemaBehind, veritabanı şeması binary'nin beklediğinden eskiyse döner
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// Check, şema güncelse nil döner; /readyz ve açılış kontrolü kullanır
+func (m *Migrator) Check(ctx context.Context) error {
+	current, err := m.Current(ctx)
+	if err != nil {
+		return err
+	}
+	if current < m.Latest() {
+		return fmt.Errorf("%w: at version %d, expected %d", ErrSchemaBehind, current, m.Latest())
+	}
+	return nil
+}
+
+// EnsureSchema, açılışta çağrılır. Şema geride ise autoApply açıksa bekleyen migration'ları uygular,
+// değilse hata döner ve sunucu başlamaz. Şema binary'den yeniyse (eski sürüme dönüş) sadece uyarır.
+func EnsureSchema(ctx context.Context, m *Migrator, autoApply bool) error {
+	current, err := m.Current(ctx)
+	if err != nil {
+		return err
+	}
+	switch latest := m.Latest(); {
+	case current > latest:
+		slog.WarnContext(ctx, "database schema is newer than this build", "current", current, "expected", latest)
+	case current < latest && autoApply:
+		_, err := m.Up(ctx)
+		return err
+	case current < latest:
+		return fmt.Errorf("%w: at version %d, expected %d; run `migrate up` or set database.automigrate=true",
+			ErrSchemaBehind, current, latest)
 	}
+	return nil
 }
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(s string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(s)} }
+	tests := []struct {
+		name     string
+		fs       fstest.MapFS
+		versions []int
+		wantErr  string
+	}{
+		{"sorted by version", fstest.MapFS{
+			"migrations/0010_c.up.sql":   file("c"),
+			"migrations/0010_c.down.sql": file("c"),
+			"migrations/0002_b.up.sql":   file("b"),
+			"migrations/0002_b.down.sql": file("b"),
+			"migrations/0001_a.up.sql":   file("a"),
+			"migrations/0001_a.down.sql": file("a"),
+		}, []int{1, 2, 10}, ""},
+		{"empty", fstest.MapFS{}, []int{}, ""},
+		{"missing down", fstest.MapFS{
+			"migrations/0001_a.up.sql": file("a"),
+		}, nil, "needs both up and down"},
+		{"bad name", fstest.MapFS{
+			"migrations/init.sql": file("a"),
+		}, nil, "invalid file name"},
+		{"version used twice", fstest.MapFS{
+			"migrations/0001_a.up.sql":   file("a"),
+			"migrations/0001_a.down.sql": file("a"),
+			"migrations/0001_b.up.sql":   file("b"),
+			"migrations/0001_b.down.sql": file("b"),
+		}, nil, "version 1 used by"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migs, err := loadMigrations(tt.fs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			versions := []int{}
+			for _, m := range migs {
+				versions = append(versions, m.Version)
+			}
+			if !reflect.DeepEqual(versions, tt.versions) {
+				t.Errorf("versions = %v, want %v", versions, tt.versions)
+			}
+		})
+	}
+}
+
+// gömülü dosyalar 1'den başlayıp boşluksuz artmalı
+func TestEmbeddedMigrations(t *testing.T) {
+	m, err := NewMigrator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, mig := range m.migrations {
+		if mig.Version != i+1 {
+			t.Fatalf("migration %04d_%s: expected version %d", mig.Version, mig.Name, i+1)
+		}
+	}
+}
+
+func TestMigratorPlan(t *testing.T) {
+	m := &Migrator{migrations: []Migration{{Version: 1}, {Version: 2}, {Version: 5}, {Version: 7}}}
+	tests := []struct {
+		current, target int
+		want            []int
+	}{
+		{0, 7, []int{1, 2, 5, 7}},
+		{2, 7, []int{5, 7}},
+		{2, 5, []int{5}},
+		{7, 7, nil},
+		{7, 5, []int{7}},
+		{7, 1, []int{7, 5, 2}},
+		{7, 0, []int{7, 5, 2, 1}},
+		{0, 0, nil},
+		// veritabanı binary'den yeniyse bilinmeyen versiyonlar atlanır
+		{9, 5, []int{7}},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, mig := range m.plan(tt.current, tt.target) {
+			got = append(got, mig.Version)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("plan(%d, %d) = %v, want %v", tt.current, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestMigratorPrevious(t *testing.T) {
+	m := &Migrator{migrations: []Migration{{Version: 1}, {Version: 2}, {Version: 5}}}
+	tests := []struct{ version, want int }{{5, 2}, {2, 1}, {1, 0}, {0, 0}, {3, 2}}
+	for _, tt := range tests {
+		if got := m.Previous(tt.version); got != tt.want {
+			t.Errorf("Previous(%d) = %d, want %d", tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+-- Tüm şemayı siler, veriler geri gelmez
+DROP TABLE IF EXISTS "role_requests";
+DROP TABLE IF EXISTS "blogs";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,51 @@
+-- Başlangıç şeması: ilk sürümün AutoMigrate ile kurduğu tablolar (users, blogs, role_requests).
+-- Sonraki sürümlerde eklenen tablo ve kolonlar 0002 ve sonrasındadır; hepsi IF NOT EXISTS ile
+-- yazıldığı için AutoMigrate'in herhangi bir sürümüyle kurulmuş veritabanı da sırayla güncellenir.
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "username" varchar(100),
+    "email" varchar(100),
+    "password" varchar(100),
+    "role" varchar(100),
+    "followers" varchar(100),
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_username" UNIQUE ("username"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "blogs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "title" text,
+    "body" text,
+    "author_id" bigint,
+    "username" text,
+    "type" text,
+    "is_approved" boolean,
+    "status" text,
+    "tags" text,
+    "category" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_blogs_deleted_at" ON "blogs" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "role_requests" (
+    "id" bigserial,
+    "username" text,
+    "requested_role" text,
+    "status" text DEFAULT 'pending',
+    "reason" text,
+    "decided_by" text,
+    "decided_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_role_requests_username" ON "role_requests" ("username");
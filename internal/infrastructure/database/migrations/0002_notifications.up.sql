@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "username" text,
+    "type" text,
+    "message" text,
+    "link" text,
+    "read_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_notifications_username" ON "notifications" ("username");
+CREATE INDEX IF NOT EXISTS "idx_notifications_deleted_at" ON "notifications" ("deleted_at");
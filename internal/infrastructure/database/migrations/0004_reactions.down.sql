@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "blog_reaction_counts";
+DROP TABLE IF EXISTS "reactions";
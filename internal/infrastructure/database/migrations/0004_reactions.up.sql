@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "reactions" (
+    "id" bigserial,
+    "blog_id" bigint,
+    "user_id" bigint,
+    "type" varchar(32),
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_reactions_user_type" ON "reactions" ("user_id","type");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_reactions_blog_user_type" ON "reactions" ("blog_id","user_id","type");
+
+CREATE TABLE IF NOT EXISTS "blog_reaction_counts" (
+    "blog_id" bigint,
+    "type" varchar(32),
+    "count" bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("blog_id","type"),
+    CONSTRAINT "fk_blogs_reaction_counts" FOREIGN KEY ("blog_id") REFERENCES "blogs"("id")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "bookmarks";
+DROP TABLE IF EXISTS "reading_lists";
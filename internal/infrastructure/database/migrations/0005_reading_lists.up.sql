@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS "reading_lists" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" bigint,
+    "username" text,
+    "name" varchar(100),
+    "slug" varchar(120),
+    "description" text,
+    "is_public" boolean DEFAULT false,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_reading_lists_username" ON "reading_lists" ("username");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_reading_lists_user_slug" ON "reading_lists" ("user_id","slug") WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_reading_lists_deleted_at" ON "reading_lists" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "bookmarks" (
+    "id" bigserial,
+    "user_id" bigint,
+    "reading_list_id" bigint DEFAULT 0,
+    "blog_id" bigint,
+    "blog_title" text,
+    "note" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_bookmarks_blog_id" ON "bookmarks" ("blog_id");
+CREATE INDEX IF NOT EXISTS "idx_bookmarks_reading_list_id" ON "bookmarks" ("reading_list_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_bookmarks_user_list_blog" ON "bookmarks" ("user_id","reading_list_id","blog_id");
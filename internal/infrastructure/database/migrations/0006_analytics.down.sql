@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "blog_daily_stats";
+DROP TABLE IF EXISTS "blog_views";
+DROP TABLE IF EXISTS "comments";
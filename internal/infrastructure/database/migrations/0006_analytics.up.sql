@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS "comments" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "blog_id" bigint,
+    "user_id" bigint,
+    "content" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_blogs_comments" FOREIGN KEY ("blog_id") REFERENCES "blogs"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_comments_deleted_at" ON "comments" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "blog_views" (
+    "id" bigserial,
+    "blog_id" bigint,
+    "viewer_key" varchar(100),
+    "viewed_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_blog_views_viewed_at" ON "blog_views" ("viewed_at");
+CREATE INDEX IF NOT EXISTS "idx_blog_views_blog_viewer_time" ON "blog_views" ("blog_id","viewer_key","viewed_at");
+
+CREATE TABLE IF NOT EXISTS "blog_daily_stats" (
+    "blog_id" bigint,
+    "day" date,
+    "views" bigint NOT NULL DEFAULT 0,
+    "unique_readers" bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("blog_id","day")
+);
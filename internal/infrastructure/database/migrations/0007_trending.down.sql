@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "job_checkpoints";
+DROP TABLE IF EXISTS "trending_scores";
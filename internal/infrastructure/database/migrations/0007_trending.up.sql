@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "trending_scores" (
+    "blog_id" bigint,
+    "period" varchar(10),
+    "score" decimal,
+    "last_activity_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("blog_id","period")
+);
+CREATE INDEX IF NOT EXISTS "idx_trending_period_score" ON "trending_scores" ("period","score" desc);
+
+CREATE TABLE IF NOT EXISTS "job_checkpoints" (
+    "name" varchar(100),
+    "at" timestamptz,
+    PRIMARY KEY ("name")
+);
@@ -0,0 +1,4 @@
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "seo_meta_title";
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "seo_meta_description";
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "seo_canonical_url";
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "seo_og_image";
@@ -0,0 +1,4 @@
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "seo_meta_title" varchar(120);
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "seo_meta_description" varchar(320);
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "seo_canonical_url" text;
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "seo_og_image" text;
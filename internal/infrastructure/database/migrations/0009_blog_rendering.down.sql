@@ -0,0 +1,6 @@
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "body_format";
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "body_html";
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "excerpt";
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "word_count";
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "reading_time";
+ALTER TABLE "blogs" DROP COLUMN IF EXISTS "toc";
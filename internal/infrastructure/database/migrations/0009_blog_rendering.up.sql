@@ -0,0 +1,7 @@
+-- mevcut bloglar 'plain' olarak işaretlenir, render alanlarını arka plan işi doldurur
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "body_format" varchar(16) DEFAULT 'plain';
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "body_html" text;
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "excerpt" varchar(400);
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "word_count" bigint;
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "reading_time" bigint;
+ALTER TABLE "blogs" ADD COLUMN IF NOT EXISTS "toc" jsonb;
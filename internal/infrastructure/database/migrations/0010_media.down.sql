@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "blog_media";
+DROP TABLE IF EXISTS "media";
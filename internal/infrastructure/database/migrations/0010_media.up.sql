@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS "media" (
+    "id" bigserial,
+    "user_id" bigint,
+    "username" text,
+    "key" varchar(255),
+    "thumb_key" varchar(255),
+    "original_name" text,
+    "content_type" varchar(64),
+    "size" bigint,
+    "width" bigint,
+    "height" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_media_created_at" ON "media" ("created_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_media_key" ON "media" ("key");
+CREATE INDEX IF NOT EXISTS "idx_media_user_id" ON "media" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "blog_media" (
+    "blog_id" bigint,
+    "media_id" bigint,
+    PRIMARY KEY ("blog_id","media_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_blog_media_media_id" ON "blog_media" ("media_id");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "import_mappings";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "must_reset_password";
@@ -0,0 +1,13 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "must_reset_password" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS "import_mappings" (
+    "id" bigserial,
+    "source" varchar(32),
+    "source_site" varchar(255),
+    "kind" varchar(32),
+    "source_id" varchar(64),
+    "target_id" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_import_mappings_source" ON "import_mappings" ("source","source_site","kind","source_id");
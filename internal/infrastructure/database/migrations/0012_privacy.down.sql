@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "audit_logs";
+DROP TABLE IF EXISTS "erasure_requests";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "erased_at";
@@ -0,0 +1,29 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "erased_at" timestamptz;
+
+CREATE TABLE IF NOT EXISTS "erasure_requests" (
+    "id" bigserial,
+    "user_id" bigint,
+    "status" varchar(16),
+    "scheduled_at" timestamptz,
+    "completed_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_erasure_requests_scheduled_at" ON "erasure_requests" ("scheduled_at");
+CREATE INDEX IF NOT EXISTS "idx_erasure_requests_status" ON "erasure_requests" ("status");
+CREATE INDEX IF NOT EXISTS "idx_erasure_requests_user_id" ON "erasure_requests" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    "id" bigserial,
+    "actor" varchar(100),
+    "action" varchar(64),
+    "target_type" varchar(32),
+    "target_id" bigint,
+    "details" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_created_at" ON "audit_logs" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_action" ON "audit_logs" ("action");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_actor" ON "audit_logs" ("actor");
@@ -0,0 +1 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "locale" varchar(16) NOT NULL DEFAULT '';
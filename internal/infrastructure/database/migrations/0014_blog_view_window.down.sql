@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_blog_views_window";
+ALTER TABLE "blog_views" DROP COLUMN IF EXISTS "window_start";
@@ -0,0 +1,4 @@
+-- Okuma tekilleştirmesi unique index ile: aynı okuyucu aynı window diliminde bir kez sayılır.
+-- Eski kayıtlarda window_start boş kalır; NULL'lar birbirine eşit sayılmadığı için index'e takılmaz.
+ALTER TABLE "blog_views" ADD COLUMN IF NOT EXISTS "window_start" timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_blog_views_window" ON "blog_views" ("blog_id","viewer_key","window_start");
@@ -0,0 +1,2 @@
+ALTER TABLE "blog_views" ADD COLUMN IF NOT EXISTS "window_start" timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_blog_views_window" ON "blog_views" ("blog_id","viewer_key","window_start");
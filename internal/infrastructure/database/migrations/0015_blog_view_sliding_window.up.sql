@@ -0,0 +1,4 @@
+-- Okuma tekilleştirmesi sabit dilim yerine kayan pencereyle (son ViewWindow içindeki okuma) ve
+-- advisory lock'la yapılır; dilim başı kolonu ve unique index artık kullanılmıyor.
+DROP INDEX IF EXISTS "idx_blog_views_window";
+ALTER TABLE "blog_views" DROP COLUMN IF EXISTS "window_start";
@@ -3,23 +3,37 @@ package database
 import (
 	"cleanArch_with_postgres/internal/infrastructure/config"
 	"fmt"
+	"log/slog"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
-func New(config config.DBConfig) *gorm.DB {
-	dsn := fmt.Sprintf(
+// Open, bağlantıyı açar; şema burada değiştirilmez, bkz. EnsureSchema ve `migrate` komutu
+func Open(config config.DBConfig, logCfg config.LogConfig) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(DSN(config)), &gorm.Config{Logger: newLogger(logCfg)})
+}
+
+// DSN, gorm dışında doğrudan bağlantı açması gereken bileşenler (ör. LISTEN/NOTIFY) için de kullanılır
+func DSN(config config.DBConfig) string {
+	return fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		config.Host, config.Username, config.Password, config.Name, config.Port,
 	)
+}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		panic(err)
+// newLogger, GORM loglarını slog'a yönlendirir. Sorgular parametresiz yazılır ki şifre hash'i,
+// token gibi değerler loga düşmesin; debug seviyesinde tüm sorgular, aksi halde yavaş ve hatalı olanlar.
+func newLogger(cfg config.LogConfig) logger.Interface {
+	level := logger.Warn
+	if cfg.Level == "debug" {
+		level = logger.Info
 	}
-
-	AutoMigrate(db)
-
-	return db
+	return logger.NewSlogLogger(slog.Default(), logger.Config{
+		LogLevel:                  level,
+		SlowThreshold:             cfg.SlowQuery,
+		ParameterizedQueries:      true,
+		IgnoreRecordNotFoundError: true,
+	})
 }
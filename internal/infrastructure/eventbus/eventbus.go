@@ -0,0 +1,66 @@
+package eventbus
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Event tipleri
+const (
+	NotificationCreated = "notification.created"
+	BlogPendingApproval = "blog.pending_approval"
+	RoleRequestCreated  = "role_request.created"
+)
+
+type Event struct {
+	Type       string          `json:"type"`
+	Recipient  string          `json:"recipient,omitempty"`   // dolu ise sadece bu kullanıcıya gider
+	AdminsOnly bool            `json:"admins_only,omitempty"` // sadece adminlere gider
+	Data       json.RawMessage `json:"data"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// NewEvent, data'yı JSON'a çevirip event oluşturur
+func NewEvent(eventType string, data interface{}) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{Type: eventType, Data: raw, CreatedAt: time.Now()}, nil
+}
+
+// VisibleTo, event'in verilen kullanıcıya gönderilip gönderilmeyeceğini söyler
+func (e Event) VisibleTo(username, role string) bool {
+	if e.Recipient != "" {
+		return e.Recipient == username
+	}
+	if e.AdminsOnly {
+		return role == "admin"
+	}
+	return true
+}
+
+// Bus, process içi (memory) veya Postgres LISTEN/NOTIFY tabanlı olabilir.
+// Subscribe dönen cancel fonksiyonu çağrılınca kanal kapanır.
+type Bus interface {
+	Publish(ctx context.Context, e Event) error
+	Subscribe(buffer int) (<-chan Event, func())
+	Close() error
+}
+
+// New, config'teki driver'a göre bus oluşturur
+func New(cfg config.EventsConfig, db *gorm.DB, dsn string) (Bus, error) {
+	switch cfg.Driver {
+	case "", "memory":
+		return NewMemoryBus(), nil
+	case "postgres":
+		return NewPostgresBus(db, dsn), nil
+	default:
+		return nil, fmt.Errorf("unknown events driver: %q", cfg.Driver)
+	}
+}
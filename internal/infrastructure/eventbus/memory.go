@@ -0,0 +1,82 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+)
+
+type memoryBus struct {
+	mu     sync.RWMutex
+	subs   map[int]chan Event
+	nextID int
+	closed bool
+}
+
+func NewMemoryBus() Bus {
+	return newMemoryBus()
+}
+
+func newMemoryBus() *memoryBus {
+	return &memoryBus{subs: map[int]chan Event{}}
+}
+
+func (b *memoryBus) Publish(ctx context.Context, e Event) error {
+	b.dispatch(e)
+	return nil
+}
+
+// dispatch, event'i tüm abonelere iletir. Yavaş aboneler yüzünden publish bloklanmasın diye
+// kanalı dolu olan aboneye event düşürülür.
+func (b *memoryBus) dispatch(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+func (b *memoryBus) Subscribe(buffer int) (<-chan Event, func()) {
+	if buffer <= 0 {
+		buffer = 16
+	}
+	ch := make(chan Event, buffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if c, ok := b.subs[id]; ok {
+				delete(b.subs, id)
+				close(c)
+			}
+		})
+	}
+}
+
+func (b *memoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for id, ch := range b.subs {
+		delete(b.subs, id)
+		close(ch)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+const pgChannel = "blog_events"
+
+// postgresBus, event'leri pg_notify ile yayınlar ve ayrı bir bağlantıda LISTEN ederek
+// gelenleri yerel abonelere dağıtır. Böylece birden fazla server instance'ı aynı event'leri alır.
+// Not: NOTIFY payload'ı 8000 byte ile sınırlı, event'ler küçük tutulmalı.
+type postgresBus struct {
+	*memoryBus
+	db     *gorm.DB
+	dsn    string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewPostgresBus(db *gorm.DB, dsn string) Bus {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &postgresBus{
+		memoryBus: newMemoryBus(),
+		db:        db,
+		dsn:       dsn,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	go b.listen(ctx)
+	return b
+}
+
+func (b *postgresBus) Publish(ctx context.Context, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", pgChannel, string(payload)).Error
+}
+
+func (b *postgresBus) listen(ctx context.Context) {
+	defer close(b.done)
+	backoff := time.Second
+	for {
+		listened, err := b.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		// bağlantı kurulup LISTEN başarılı olduysa bu kesinti yeni sayılır; önceki uzun kesintinin
+		// backoff'u kısa kopmalarda beklenmesin
+		if listened {
+			backoff = time.Second
+		}
+		slog.Warn("eventbus listen error", "err", err, "retry_in", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// listenOnce, bağlantı koparsa döner; LISTEN başarılı olduysa listened true
+func (b *postgresBus) listenOnce(ctx context.Context) (listened bool, err error) {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgChannel); err != nil {
+		return false, err
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+		var e Event
+		if err := json.Unmarshal([]byte(n.Payload), &e); err != nil {
+			slog.Error("eventbus decode error", "err", err)
+			continue
+		}
+		b.dispatch(e)
+	}
+}
+
+func (b *postgresBus) Close() error {
+	b.cancel()
+	<-b.done
+	return b.memoryBus.Close()
+}
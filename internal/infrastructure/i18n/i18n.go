@@ -0,0 +1,157 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// locales altındaki her <dil>.json bir katalogdur: {"errors.blog_not_found": "..."}.
+// Yeni dil eklemek için dosya eklemek yeterli; i18n.dir verilirse oradaki dosyalar da yüklenir.
+//
+//go:embed locales/*.json
+var embedded embed.FS
+
+// Catalog, dil -> anahtar -> mesaj
+type Catalog struct {
+	def      string
+	messages map[string]map[string]string
+}
+
+var current = mustLoadEmbedded()
+
+func mustLoadEmbedded() *Catalog {
+	c := &Catalog{def: "tr", messages: map[string]map[string]string{}}
+	if err := c.loadFS(embedded, "locales"); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Load, gömülü katalogların üzerine dir'deki dosyaları ekler (aynı anahtarlar ezilir) ve paket
+// genelinde kullanılan katalog olarak ayarlar
+func Load(dir, defaultLocale string) error {
+	c := mustLoadEmbedded()
+	if dir != "" {
+		if err := c.loadFS(os.DirFS(dir), "."); err != nil {
+			return err
+		}
+	}
+	defaultLocale = normalize(defaultLocale)
+	if _, ok := c.messages[defaultLocale]; !ok {
+		return fmt.Errorf("i18n: no catalog for default locale %q", defaultLocale)
+	}
+	c.def = defaultLocale
+	current = c
+	return nil
+}
+
+func (c *Catalog) loadFS(fsys fs.FS, root string) error {
+	files, err := fs.Glob(fsys, path.Join(root, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		raw, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		var msgs map[string]string
+		if err := json.Unmarshal(raw, &msgs); err != nil {
+			return fmt.Errorf("i18n: %s: %w", name, err)
+		}
+		locale := normalize(strings.TrimSuffix(path.Base(name), ".json"))
+		if c.messages[locale] == nil {
+			c.messages[locale] = map[string]string{}
+		}
+		for k, v := range msgs {
+			c.messages[locale][k] = v
+		}
+	}
+	return nil
+}
+
+func Default() string {
+	return current.def
+}
+
+// Supported, locale için katalog var mı
+func Supported(locale string) bool {
+	_, ok := current.messages[normalize(locale)]
+	return ok
+}
+
+// Locales, yüklü diller (sıralı)
+func Locales() []string {
+	out := make([]string, 0, len(current.messages))
+	for l := range current.messages {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Match, Accept-Language header'ından desteklenen en uygun dili seçer; yoksa varsayılan dil.
+// "en-US" için önce "en-us", sonra "en" kataloğu aranır.
+func Match(acceptLanguage string) string {
+	type pref struct {
+		tag string
+		q   float64
+	}
+	var prefs []pref
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if q > 0 {
+			prefs = append(prefs, pref{tag: normalize(tag), q: q})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+
+	for _, p := range prefs {
+		if p.tag == "*" {
+			break
+		}
+		if Supported(p.tag) {
+			return p.tag
+		}
+		if base, _, ok := strings.Cut(p.tag, "-"); ok && Supported(base) {
+			return base
+		}
+	}
+	return current.def
+}
+
+// T, anahtarın locale'deki karşılığını döner; yoksa varsayılan dile, o da yoksa fallback'e düşer.
+// Mesajdaki {isim} yer tutucuları params ile doldurulur.
+func T(locale, key, fallback string, params map[string]string) string {
+	msg, ok := current.messages[normalize(locale)][key]
+	if !ok {
+		msg, ok = current.messages[current.def][key]
+	}
+	if !ok {
+		return fallback
+	}
+	for k, v := range params {
+		msg = strings.ReplaceAll(msg, "{"+k+"}", v)
+	}
+	return msg
+}
+
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
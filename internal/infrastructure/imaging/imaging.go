@@ -0,0 +1,98 @@
+// Package imaging, yüklenen görsellerin tipini içerikten tespit eder ve küçük resim üretir.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif" // gif decode (ilk kare)
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // webp decode
+)
+
+// Desteklenen tipler ve dosya uzantıları
+var Extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+var (
+	ErrUnsupported = errors.New("unsupported image type, expected jpeg|png|gif|webp")
+	// ErrTooManyPixels, küçük dosyada çok büyük boyut bildiren görsellerde (decompression bomb) döner
+	ErrTooManyPixels = errors.New("image dimensions are too large")
+)
+
+// Sniff, istemcinin gönderdiği Content-Type'a güvenmeden ilk byte'lardan tipi belirler
+func Sniff(data []byte) (string, error) {
+	ct := http.DetectContentType(data)
+	if _, ok := Extensions[ct]; !ok {
+		return "", ErrUnsupported
+	}
+	return ct, nil
+}
+
+type Image struct {
+	Width       int
+	Height      int
+	Thumb       []byte
+	ThumbType   string
+	ThumbWidth  int
+	ThumbHeight int
+}
+
+// Process, görselin boyutlarını okur ve genişliği maxWidth'i geçmeyen bir küçük resim üretir.
+// Küçük resim, kaynak jpeg ise jpeg; diğerlerinde saydamlık korunsun diye png. Decode bellekte
+// genişlik*yükseklik kadar yer açtığı için boyutlar önce başlıktan okunur ve maxPixels'i aşan görsel
+// decode edilmeden reddedilir (maxPixels <= 0 ise sınır yok).
+func Process(data []byte, maxWidth, maxPixels int) (*Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+	if maxPixels > 0 && int64(cfg.Width)*int64(cfg.Height) > int64(maxPixels) {
+		return nil, ErrTooManyPixels
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+	b := src.Bounds()
+	out := &Image{Width: b.Dx(), Height: b.Dy()}
+	if out.Width == 0 || out.Height == 0 {
+		return nil, ErrUnsupported
+	}
+
+	tw, th := out.Width, out.Height
+	if maxWidth > 0 && tw > maxWidth {
+		tw = maxWidth
+		th = out.Height * maxWidth / out.Width
+		if th < 1 {
+			th = 1
+		}
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+
+	var buf bytes.Buffer
+	ct, _ := Sniff(data)
+	if ct == "image/jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 82})
+		out.ThumbType = "image/jpeg"
+	} else {
+		err = png.Encode(&buf, dst)
+		out.ThumbType = "image/png"
+	}
+	if err != nil {
+		return nil, err
+	}
+	out.Thumb = buf.Bytes()
+	out.ThumbWidth, out.ThumbHeight = tw, th
+	return out, nil
+}
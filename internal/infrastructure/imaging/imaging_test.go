@@ -0,0 +1,50 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessPixelCap(t *testing.T) {
+	tests := []struct {
+		name      string
+		w, h      int
+		maxPixels int
+		wantErr   error
+	}{
+		{"under cap", 100, 50, 10_000, nil},
+		{"exactly cap", 100, 100, 10_000, nil},
+		{"over cap", 101, 100, 10_000, ErrTooManyPixels},
+		// tek renk olduğu için dosya küçük, boyut büyük
+		{"small file large dimensions", 4000, 4000, 1_000_000, ErrTooManyPixels},
+		{"no cap", 300, 200, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := Process(encodePNG(t, tt.w, tt.h), 64, tt.maxPixels)
+			if err != tt.wantErr {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (img.Width != tt.w || img.Height != tt.h || img.ThumbWidth > 64) {
+				t.Errorf("got %dx%d thumb %d, want %dx%d thumb <= 64", img.Width, img.Height, img.ThumbWidth, tt.w, tt.h)
+			}
+		})
+	}
+}
+
+func TestProcessRejectsGarbage(t *testing.T) {
+	if _, err := Process([]byte("not an image"), 64, 0); err != ErrUnsupported {
+		t.Errorf("err = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry, uygulamanın tüm metrikleri; varsayılan global registry yerine bu kullanılır
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by method, route template and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "GORM query duration by operation and table.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	DBQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Failed GORM queries by operation and table (record not found is not counted).",
+	}, []string{"operation", "table"})
+)
+
+// Domain sayaçları
+var (
+	Registrations = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "users_registered_total",
+		Help: "Registered users.",
+	})
+
+	Logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "user_logins_total",
+		Help: "Login attempts by result (success, failure).",
+	}, []string{"result"})
+
+	BlogsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "blogs_created_total",
+		Help: "Created blogs.",
+	})
+
+	BlogsApproved = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "blogs_approved_total",
+		Help: "Blogs approved by an admin.",
+	})
+
+	RoleRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "role_requests_total",
+		Help: "Role requests by action (requested, approved, rejected).",
+	}, []string{"action"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests, HTTPDuration, DBQueryDuration, DBQueryErrors,
+		Registrations, Logins, BlogsCreated, BlogsApproved, RoleRequests,
+	)
+}
+
+// Handler, Registry'yi Prometheus text formatında sunar
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
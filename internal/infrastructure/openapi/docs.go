@@ -0,0 +1,8 @@
+package openapi
+
+import _ "embed"
+
+// DocsHTML, openapi.json'ı okuyup gösteren bağımlılıksız doküman sayfası
+//
+//go:embed docs.html
+var DocsHTML []byte
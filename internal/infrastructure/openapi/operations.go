@@ -0,0 +1,125 @@
+package openapi
+
+import "cleanArch_with_postgres/internal/viewmodel"
+
+var (
+	limitParam = func(def string) Param {
+		return Param{Name: "limit", Type: "integer", Description: "Max items (default " + def + ")"}
+	}
+	includeDeleted = Param{Name: "include_deleted", Type: "boolean", Description: "Include soft-deleted records (admin)"}
+	feedFormat     = Param{Name: "format", Enum: []string{"rss", "atom"}, Description: "Feed format (default rss)"}
+	feedTypes      = []string{"application/rss+xml", "application/atom+xml"}
+)
+
+// Operations, router'da kayıtlı tüm route'ların dokümanı. Router'a route eklendiğinde buraya da
+// eklenmeli; eksikler açılışta loglanır ve `openapi check` komutu hata verir.
+var Operations = []Operation{
+	// Docs
+	{Method: "GET", Path: "/api/v1/openapi.json", Tag: "docs", Summary: "OpenAPI document", Public: true, Raw: map[string]interface{}{}},
+	{Method: "GET", Path: "/api/v1/docs", Tag: "docs", Summary: "API docs UI", Public: true, ContentType: []string{"text/html"}},
+
+	// Health
+	{Method: "GET", Path: "/healthz", Tag: "health", Summary: "Liveness probe", Public: true, Raw: viewmodel.HealthVM{}},
+	{Method: "GET", Path: "/readyz", Tag: "health", Summary: "Readiness probe (503 while a check fails or during shutdown)", Public: true, Raw: viewmodel.HealthVM{}},
+	{Method: "GET", Path: "/version", Tag: "health", Summary: "Build and schema version", Public: true, Raw: viewmodel.VersionVM{}},
+
+	// Feeds & sitemap
+	{Method: "GET", Path: "/feeds", Tag: "feeds", Summary: "Site feed", Public: true, Query: []Param{feedFormat}, ContentType: feedTypes},
+	{Method: "GET", Path: "/feeds/user/:username", Tag: "feeds", Summary: "Author feed", Public: true, Query: []Param{feedFormat}, ContentType: feedTypes},
+	{Method: "GET", Path: "/feeds/tag/:tag", Tag: "feeds", Summary: "Tag feed", Public: true, Query: []Param{feedFormat}, ContentType: feedTypes},
+	{Method: "GET", Path: "/feeds/category/:category", Tag: "feeds", Summary: "Category feed", Public: true, Query: []Param{feedFormat}, ContentType: feedTypes},
+	{Method: "GET", Path: "/sitemap.xml", Tag: "feeds", Summary: "Sitemap index or single sitemap", Public: true, ContentType: []string{"application/xml"}},
+	{Method: "GET", Path: "/sitemap-:page.xml", Tag: "feeds", Summary: "Sitemap page", Public: true, ContentType: []string{"application/xml"}},
+	{Method: "GET", Path: "/media/files/*", Tag: "media", Summary: "Uploaded file (local storage driver only)", Public: true, ContentType: []string{"image/*"}},
+
+	// Auth
+	{Method: "POST", Path: "/api/v1/register", Tag: "auth", Summary: "Register", Public: true, Body: viewmodel.RegisterRequest{}, Status: 201, Data: viewmodel.RegisterResponse{}, Message: true},
+	{Method: "POST", Path: "/api/v1/login", Tag: "auth", Summary: "Log in with username or email", Public: true, Body: viewmodel.LoginRequest{}, Data: viewmodel.LoginResponse{}, Message: true},
+	{Method: "GET", Path: "/api/v1/events/stream", Tag: "notifications", Summary: "Server-sent events", QueryToken: true, ContentType: []string{"text/event-stream"}},
+
+	// Users
+	{Method: "GET", Path: "/api/v1/users", Tag: "users", Summary: "Search users (autocomplete)", Query: []Param{{Name: "search"}, limitParam("10"), includeDeleted}, Data: []viewmodel.UserVM{}},
+	{Method: "GET", Path: "/api/v1/user/:username", Tag: "users", Summary: "Get user", Data: viewmodel.UserVM{}},
+	{Method: "PUT", Path: "/api/v1/user/:username", Tag: "users", Summary: "Update user (self or admin)", Body: viewmodel.UpdateRequest{}, Data: viewmodel.UpdateResponse{}, Message: true},
+	{Method: "DELETE", Path: "/api/v1/user/:username", Tag: "users", Summary: "Soft-delete user (self or admin)", Message: true},
+	{Method: "PUT", Path: "/api/v1/user/:username/restore", Tag: "users", Summary: "Restore deleted user (admin)", Message: true},
+	{Method: "GET", Path: "/api/v1/user/:username/reading-lists", Tag: "reading-lists", Summary: "Public reading lists of a user", Data: []viewmodel.ReadingListVM{}},
+	{Method: "GET", Path: "/api/v1/user/:username/reading-lists/:slug", Tag: "reading-lists", Summary: "Public reading list by slug", Data: viewmodel.ReadingListVM{}},
+
+	// Me
+	{Method: "GET", Path: "/api/v1/me", Tag: "me", Summary: "Current user", Data: viewmodel.UserVM{}},
+	{Method: "PUT", Path: "/api/v1/me", Tag: "me", Summary: "Update current user", Body: viewmodel.UpdateRequest{}, Data: viewmodel.UpdateResponse{}, Message: true},
+	{Method: "DELETE", Path: "/api/v1/me", Tag: "me", Summary: "Delete current user", Message: true},
+	{Method: "GET", Path: "/api/v1/me/liked", Tag: "reactions", Summary: "Blogs liked by current user", Query: []Param{limitParam("50")}, Data: []viewmodel.BlogVM{}},
+	{Method: "GET", Path: "/api/v1/me/blogs/export", Tag: "me", Summary: "Export own blogs as zip (markdown + YAML front matter)", ContentType: []string{"application/zip"}},
+	{Method: "POST", Path: "/api/v1/me/blogs/import", Tag: "me", Summary: "Import blogs from zip or markdown archive as unapproved drafts", Form: []string{"archive"}, Data: viewmodel.ImportReportVM{}},
+	{Method: "GET", Path: "/api/v1/me/data-export", Tag: "privacy", Summary: "Download personal data", Raw: viewmodel.PersonalDataVM{}},
+	{Method: "GET", Path: "/api/v1/me/erasure", Tag: "privacy", Summary: "Pending account erasure", Data: &viewmodel.ErasureRequestVM{}},
+	{Method: "POST", Path: "/api/v1/me/erasure", Tag: "privacy", Summary: "Schedule account erasure", Body: viewmodel.ErasureConfirmVM{}, Status: 202, Data: viewmodel.ErasureRequestVM{}, Message: true},
+	{Method: "DELETE", Path: "/api/v1/me/erasure", Tag: "privacy", Summary: "Cancel scheduled erasure", Message: true},
+
+	// Blogs
+	{Method: "GET", Path: "/api/v1/blogs", Tag: "blogs", Summary: "List blogs", Query: []Param{includeDeleted}, Data: []viewmodel.BlogVM{}},
+	{Method: "GET", Path: "/api/v1/blogs/trending", Tag: "blogs", Summary: "Trending blogs", Query: []Param{{Name: "window", Enum: []string{"day", "week", "month"}}, limitParam("20")}, Data: []viewmodel.TrendingBlogVM{}},
+	{Method: "GET", Path: "/api/v1/blogs/:username", Tag: "blogs", Summary: "Blogs by author (`me` for current user)", Query: []Param{includeDeleted}, Data: []viewmodel.BlogVM{}, Message: true},
+	{Method: "GET", Path: "/api/v1/blogs-deleted/:username", Tag: "blogs", Summary: "Blogs by author including deleted", Data: []viewmodel.BlogVM{}},
+	{Method: "GET", Path: "/api/v1/blog/:title", Tag: "blogs", Summary: "Get blog", Data: viewmodel.BlogVM{}},
+	{Method: "POST", Path: "/api/v1/blog", Tag: "blogs", Summary: "Create blog", Body: viewmodel.BlogCreateVM{}, Status: 201, Message: true, Extra: []string{"username"}},
+	{Method: "PUT", Path: "/api/v1/blog/:title", Tag: "blogs", Summary: "Update blog", Body: viewmodel.BlogUpdateVM{}, Data: viewmodel.BlogUpdateResponse{}, Message: true},
+	{Method: "DELETE", Path: "/api/v1/blog/:title", Tag: "blogs", Summary: "Soft-delete blog", Message: true, Extra: []string{"title", "blog_author"}},
+	{Method: "PUT", Path: "/api/v1/blog/:title/approve", Tag: "blogs", Summary: "Approve blog (admin)", Message: true},
+	{Method: "PUT", Path: "/api/v1/blog/:title/unapprove", Tag: "blogs", Summary: "Unapprove blog (admin)", Message: true},
+	{Method: "PUT", Path: "/api/v1/blog/:title/restore", Tag: "blogs", Summary: "Restore deleted blog", Message: true},
+
+	// Reactions
+	{Method: "GET", Path: "/api/v1/reactions/types", Tag: "reactions", Summary: "Enabled reaction types", Data: []string{}},
+	{Method: "POST", Path: "/api/v1/blog/:title/reactions", Tag: "reactions", Summary: "React to blog (default like)", Body: viewmodel.ReactionRequest{}, BodyOptional: true, Data: viewmodel.ReactionSummaryVM{}},
+	{Method: "DELETE", Path: "/api/v1/blog/:title/reactions/:type", Tag: "reactions", Summary: "Remove reaction", Data: viewmodel.ReactionSummaryVM{}},
+
+	// Media
+	{Method: "GET", Path: "/api/v1/media", Tag: "media", Summary: "Own media and quota usage", Data: viewmodel.MediaListVM{}},
+	{Method: "POST", Path: "/api/v1/media", Tag: "media", Summary: "Upload image", Form: []string{"file"}, Status: 201, Data: viewmodel.MediaVM{}},
+	{Method: "DELETE", Path: "/api/v1/media/:id", Tag: "media", Summary: "Delete unused media", Message: true},
+
+	// Bookmarks & reading lists
+	{Method: "GET", Path: "/api/v1/bookmarks", Tag: "bookmarks", Summary: "List bookmarks", Query: []Param{{Name: "list_id", Type: "integer", Description: "Only bookmarks in this reading list"}}, Data: []viewmodel.BookmarkVM{}},
+	{Method: "POST", Path: "/api/v1/bookmarks", Tag: "bookmarks", Summary: "Bookmark a blog", Body: viewmodel.BookmarkCreateVM{}, Status: 201, Data: viewmodel.BookmarkVM{}, Message: true},
+	{Method: "PUT", Path: "/api/v1/bookmarks/:id", Tag: "bookmarks", Summary: "Update bookmark", Body: viewmodel.BookmarkUpdateVM{}, Data: viewmodel.BookmarkVM{}},
+	{Method: "DELETE", Path: "/api/v1/bookmarks/:id", Tag: "bookmarks", Summary: "Delete bookmark", Message: true},
+	{Method: "GET", Path: "/api/v1/reading-lists", Tag: "reading-lists", Summary: "Own reading lists", Data: []viewmodel.ReadingListVM{}},
+	{Method: "POST", Path: "/api/v1/reading-lists", Tag: "reading-lists", Summary: "Create reading list", Body: viewmodel.ReadingListCreateVM{}, Status: 201, Data: viewmodel.ReadingListVM{}, Message: true},
+	{Method: "GET", Path: "/api/v1/reading-lists/:id", Tag: "reading-lists", Summary: "Get reading list", Data: viewmodel.ReadingListVM{}},
+	{Method: "PUT", Path: "/api/v1/reading-lists/:id", Tag: "reading-lists", Summary: "Update reading list", Body: viewmodel.ReadingListUpdateVM{}, Data: viewmodel.ReadingListVM{}},
+	{Method: "DELETE", Path: "/api/v1/reading-lists/:id", Tag: "reading-lists", Summary: "Delete reading list", Message: true},
+
+	// Analytics
+	{Method: "GET", Path: "/api/v1/analytics/me", Tag: "analytics", Summary: "Author report", Query: []Param{{Name: "from", Description: "YYYY-MM-DD"}, {Name: "to", Description: "YYYY-MM-DD"}}, Data: viewmodel.AnalyticsReportVM{}},
+	{Method: "GET", Path: "/api/v1/analytics", Tag: "analytics", Summary: "Site report (admin)", Query: []Param{{Name: "author"}, {Name: "from", Description: "YYYY-MM-DD"}, {Name: "to", Description: "YYYY-MM-DD"}}, Data: viewmodel.AnalyticsReportVM{}},
+
+	// Role requests
+	{Method: "GET", Path: "/api/v1/role-requests", Tag: "role-requests", Summary: "List role requests (admin)", Query: []Param{{Name: "status", Enum: []string{"pending", "approved", "rejected"}}, limitParam("100")}, Data: []viewmodel.RoleRequestVM{}},
+	{Method: "POST", Path: "/api/v1/role-requests", Tag: "role-requests", Summary: "Request admin role", Body: struct {
+		Reason string `json:"reason" validate:"max=500"`
+	}{}, BodyOptional: true, Status: 201, Data: viewmodel.RoleRequestVM{}, Message: true},
+	{Method: "PUT", Path: "/api/v1/role-requests/:id/approve", Tag: "role-requests", Summary: "Approve role request (admin)", Message: true},
+	{Method: "PUT", Path: "/api/v1/role-requests/:id/reject", Tag: "role-requests", Summary: "Reject role request (admin)", Message: true},
+
+	// Notifications
+	{Method: "GET", Path: "/api/v1/notifications", Tag: "notifications", Summary: "List notifications", Query: []Param{{Name: "unread", Type: "boolean"}, limitParam("50")}, Data: []viewmodel.NotificationVM{}},
+	{Method: "PUT", Path: "/api/v1/notifications/read-all", Tag: "notifications", Summary: "Mark all as read", Message: true},
+	{Method: "PUT", Path: "/api/v1/notifications/:id/read", Tag: "notifications", Summary: "Mark as read", Message: true},
+
+	// Admin
+	{Method: "GET", Path: "/api/v1/retention/preview", Tag: "admin", Summary: "Retention purge dry-run", Data: viewmodel.PurgeReportVM{}},
+	{Method: "POST", Path: "/api/v1/import/wxr", Tag: "admin", Summary: "Import WordPress WXR export", Form: []string{"file"}, Data: viewmodel.WXRImportReportVM{}},
+
+	// Webhooks (admin)
+	{Method: "GET", Path: "/api/v1/webhooks", Tag: "webhooks", Summary: "List webhooks", Data: []viewmodel.WebhookVM{}},
+	{Method: "POST", Path: "/api/v1/webhooks", Tag: "webhooks", Summary: "Create webhook", Body: viewmodel.WebhookCreateVM{}, Status: 201, Data: viewmodel.WebhookVM{}, Message: true},
+	{Method: "GET", Path: "/api/v1/webhooks/:id", Tag: "webhooks", Summary: "Get webhook", Data: viewmodel.WebhookVM{}},
+	{Method: "PUT", Path: "/api/v1/webhooks/:id", Tag: "webhooks", Summary: "Update webhook", Body: viewmodel.WebhookUpdateVM{}, Data: viewmodel.WebhookVM{}, Message: true},
+	{Method: "DELETE", Path: "/api/v1/webhooks/:id", Tag: "webhooks", Summary: "Delete webhook", Message: true},
+	{Method: "GET", Path: "/api/v1/webhooks/:id/deliveries", Tag: "webhooks", Summary: "List deliveries", Query: []Param{{Name: "status", Enum: []string{"pending", "succeeded", "failed"}}, limitParam("100")}, Data: []viewmodel.WebhookDeliveryVM{}},
+	{Method: "GET", Path: "/api/v1/webhook-deliveries/:id", Tag: "webhooks", Summary: "Get delivery", Data: viewmodel.WebhookDeliveryVM{}},
+	{Method: "POST", Path: "/api/v1/webhook-deliveries/:id/replay", Tag: "webhooks", Summary: "Replay delivery", Status: 202, Data: viewmodel.WebhookDeliveryVM{}, Message: true},
+}
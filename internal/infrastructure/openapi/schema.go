@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Schema, OpenAPI schema nesnesi; map olduğu için JSON çıktısı anahtar sırasına göre sabittir
+type Schema map[string]interface{}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{}) // JSON'da zaman ya da null
+)
+
+// Validate tag'lerindeki özel kuralların şemadaki karşılıkları (bkz. service/validate.go)
+var rulePatterns = map[string]string{
+	"username": `^[a-zA-Z][a-zA-Z0-9_.]{2,19}$`,
+	"slug":     `^[a-z0-9]+(?:-[a-z0-9]+)*$`,
+}
+
+// schemas, viewmodel struct'larından components/schemas üretir; isimli struct'lar $ref ile bağlanır
+type schemas struct {
+	defs map[string]Schema
+}
+
+func newSchemas() *schemas {
+	return &schemas{defs: map[string]Schema{}}
+}
+
+// of, Go tipinin şemasını döner. nil tip (örn. Body verilmemiş) için nil.
+func (s *schemas) of(t reflect.Type) Schema {
+	if t == nil {
+		return nil
+	}
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+	out := s.plain(t)
+	if nullable {
+		if _, ok := out["$ref"]; ok {
+			return Schema{"allOf": []Schema{out}, "nullable": true}
+		}
+		out["nullable"] = true
+	}
+	return out
+}
+
+func (s *schemas) plain(t reflect.Type) Schema {
+	if t == timeType {
+		return Schema{"type": "string", "format": "date-time"}
+	}
+	if t == deletedAtType {
+		return Schema{"type": "string", "format": "date-time", "nullable": true}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return Schema{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return Schema{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "format": "byte"}
+		}
+		return Schema{"type": "array", "items": s.of(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": s.of(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		name := t.Name()
+		if _, ok := s.defs[name]; !ok {
+			s.defs[name] = nil // özyinelemeli tiplerde sonsuz döngüye girmesin
+			s.defs[name] = s.object(t)
+		}
+		return Schema{"$ref": "#/components/schemas/" + name}
+	}
+	// interface{} vb.: her değer olabilir
+	return Schema{}
+}
+
+// object, struct alanlarını json adlarıyla özelliklere çevirir; gömülü struct'lar düzleştirilir
+func (s *schemas) object(t reflect.Type) Schema {
+	props := map[string]Schema{}
+	var required []string
+	s.fields(t, props, &required)
+
+	out := Schema{"type": "object", "properties": props}
+	if len(required) > 0 {
+		out["required"] = required
+	}
+	return out
+}
+
+func (s *schemas) fields(t reflect.Type, props map[string]Schema, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			s.fields(f.Type, props, required)
+			continue
+		}
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := s.of(f.Type)
+		if applyRules(prop, f.Type, f.Tag.Get("validate")) {
+			*required = append(*required, name)
+		}
+		props[name] = prop
+	}
+}
+
+// applyRules, validate tag'ini şema kısıtlarına çevirir ve alan zorunluysa true döner.
+// "dive"dan sonraki kurallar dizinin elemanlarına uygulanır.
+func applyRules(prop Schema, t reflect.Type, tag string) bool {
+	if tag == "" {
+		return false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	rules := strings.Split(tag, ",")
+	required := false
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			if items, ok := prop["items"].(Schema); ok {
+				applyRules(items, t.Elem(), strings.Join(rules[i+1:], ","))
+			}
+			return required
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			prop[limitKey(name, t.Kind())] = n
+		case "oneof":
+			prop["enum"] = strings.Fields(param)
+		case "role":
+			prop["enum"] = []string{"reader", "writer", "admin"}
+		case "locale":
+			prop["enum"] = i18n.Locales()
+		case "password":
+			prop["description"] = "must contain at least one letter and one digit"
+		case "email":
+			prop["format"] = "email"
+		case "http_url":
+			prop["format"] = "uri"
+		default:
+			if p, ok := rulePatterns[name]; ok {
+				prop["pattern"] = p
+			}
+		}
+	}
+	return required
+}
+
+func limitKey(rule string, kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return rule + "Length"
+	case reflect.Slice, reflect.Array:
+		return rule + "Items"
+	case reflect.Map:
+		return rule + "Properties"
+	}
+	if rule == "min" {
+		return "minimum"
+	}
+	return "maximum"
+}
@@ -0,0 +1,278 @@
+package openapi
+
+import (
+	"cleanArch_with_postgres/internal/viewmodel"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Param, query parametresi; path parametreleri route'tan çıkarılır
+type Param struct {
+	Name        string
+	Type        string // "string" (varsayılan), "integer", "boolean"
+	Description string
+	Enum        []string
+}
+
+// Operation, tek bir route'un dokümanı. Path fiber sözdizimindedir (/blog/:title).
+// Cevap gövdesi şu önceliklerle üretilir: ContentType (JSON olmayan), Raw (zarfsız JSON),
+// yoksa {"data": Data, "message": ...} zarfı.
+type Operation struct {
+	Method     string
+	Path       string
+	Tag        string
+	Summary    string
+	Public     bool // JWT gerektirmez
+	QueryToken bool // token ?access_token= ile de verilebilir (SSE)
+	Query      []Param
+
+	Body         interface{} // JSON istek gövdesi, örn. viewmodel.BlogCreateVM{}
+	BodyOptional bool        // gövde gönderilmeyebilir
+	Form         []string    // multipart dosya alanları
+
+	Status      int         // başarılı cevabın kodu, 0 ise 200
+	Data        interface{} // zarftaki "data"nın tipi; nil ise data yok
+	Message     bool        // zarfta "message" var
+	Extra       []string    // zarftaki diğer string alanlar (örn. "username")
+	Raw         interface{} // zarfsız JSON gövdesi
+	ContentType []string    // JSON dışı cevaplar (xml, zip, event-stream)
+}
+
+var paramRe = regexp.MustCompile(`:([A-Za-z_][A-Za-z0-9_]*)`)
+
+// Path, fiber route'unu OpenAPI path'ine çevirir: /blog/:title -> /blog/{title}, /x/* -> /x/{path}
+func Path(route string) string {
+	p := paramRe.ReplaceAllString(route, "{$1}")
+	if strings.HasSuffix(p, "*") {
+		p = strings.TrimSuffix(strings.TrimSuffix(p, "*"), "/") + "/{path}"
+	}
+	return p
+}
+
+// Document, operations'tan OpenAPI 3 dokümanını üretir
+func Document(title, version string, ops []Operation) map[string]interface{} {
+	s := newSchemas()
+	problem := s.of(reflect.TypeOf(viewmodel.ProblemVM{}))
+	problemResponse := func(desc string) Schema {
+		return Schema{"description": desc, "content": Schema{viewmodel.ProblemContentType: Schema{"schema": problem}}}
+	}
+
+	paths := map[string]Schema{}
+	for _, op := range ops {
+		path := Path(op.Path)
+		if paths[path] == nil {
+			paths[path] = Schema{}
+		}
+		paths[path][strings.ToLower(op.Method)] = op.build(s)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": Schema{
+			"title":   title,
+			"version": version,
+			"description": "Errors are returned as RFC 7807 problem details (application/problem+json). " +
+				"`code` is stable and safe to match on; `detail` is localized from the user's locale or Accept-Language.",
+		},
+		"tags":  tags(ops),
+		"paths": paths,
+		"components": Schema{
+			"schemas": s.defs,
+			"securitySchemes": Schema{
+				"bearerAuth": Schema{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+				// EventSource header gönderemediği için SSE token'ı query'den de alır
+				"queryToken": Schema{"type": "apiKey", "in": "query", "name": "access_token"},
+			},
+			"parameters": Schema{
+				"AcceptLanguage": Schema{
+					"name": "Accept-Language", "in": "header",
+					"description": "Language for messages when the user has no saved locale",
+					"schema":      Schema{"type": "string", "example": "tr, en;q=0.8"},
+				},
+			},
+			"responses": Schema{
+				"BadRequest":   problemResponse("Invalid input; `errors` lists the failing fields"),
+				"Unauthorized": problemResponse("Missing or invalid token"),
+				"Forbidden":    problemResponse("Not allowed for this user"),
+				"NotFound":     problemResponse("Resource not found"),
+				"Problem":      problemResponse("Error"),
+			},
+		},
+		"security": []Schema{{"bearerAuth": []string{}}},
+	}
+}
+
+// JSON, dokümanı girintili JSON olarak döner
+func JSON(title, version string, ops []Operation) ([]byte, error) {
+	return json.MarshalIndent(Document(title, version, ops), "", "  ")
+}
+
+func (op Operation) build(s *schemas) Schema {
+	out := Schema{
+		"tags":        []string{op.Tag},
+		"summary":     op.Summary,
+		"operationId": operationID(op),
+	}
+
+	params := []Schema{{"$ref": "#/components/parameters/AcceptLanguage"}}
+	for _, m := range paramRe.FindAllStringSubmatch(op.Path, -1) {
+		params = append(params, Schema{"name": m[1], "in": "path", "required": true, "schema": Schema{"type": "string"}})
+	}
+	if strings.HasSuffix(op.Path, "*") {
+		params = append(params, Schema{"name": "path", "in": "path", "required": true, "schema": Schema{"type": "string"}})
+	}
+	for _, p := range op.Query {
+		params = append(params, p.build())
+	}
+	out["parameters"] = params
+
+	responses := Schema{"default": Schema{"$ref": "#/components/responses/Problem"}}
+	switch {
+	case op.Body != nil:
+		out["requestBody"] = Schema{
+			"required": !op.BodyOptional,
+			"content":  Schema{fiber.MIMEApplicationJSON: Schema{"schema": s.of(reflect.TypeOf(op.Body))}},
+		}
+		responses["400"] = Schema{"$ref": "#/components/responses/BadRequest"}
+	case len(op.Form) > 0:
+		props := Schema{}
+		for _, f := range op.Form {
+			props[f] = Schema{"type": "string", "format": "binary"}
+		}
+		out["requestBody"] = Schema{
+			"required": true,
+			"content": Schema{fiber.MIMEMultipartForm: Schema{"schema": Schema{
+				"type": "object", "properties": props, "required": op.Form,
+			}}},
+		}
+		responses["400"] = Schema{"$ref": "#/components/responses/BadRequest"}
+	}
+
+	switch {
+	case op.Public:
+		out["security"] = []Schema{}
+	case op.QueryToken:
+		out["security"] = []Schema{{"bearerAuth": []string{}}, {"queryToken": []string{}}}
+	}
+	if !op.Public {
+		responses["401"] = Schema{"$ref": "#/components/responses/Unauthorized"}
+		responses["403"] = Schema{"$ref": "#/components/responses/Forbidden"}
+	}
+	if strings.Contains(op.Path, ":") {
+		responses["404"] = Schema{"$ref": "#/components/responses/NotFound"}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	responses[strconv.Itoa(status)] = op.response(s, status)
+	out["responses"] = responses
+	return out
+}
+
+func (op Operation) response(s *schemas, status int) Schema {
+	out := Schema{"description": http.StatusText(status)}
+	switch {
+	case len(op.ContentType) > 0:
+		content := Schema{}
+		for _, ct := range op.ContentType {
+			content[ct] = Schema{"schema": Schema{"type": "string", "format": "binary"}}
+		}
+		out["content"] = content
+	case op.Raw != nil:
+		out["content"] = Schema{fiber.MIMEApplicationJSON: Schema{"schema": s.of(reflect.TypeOf(op.Raw))}}
+	default:
+		props := Schema{}
+		var required []string
+		if op.Data != nil {
+			props["data"] = s.of(reflect.TypeOf(op.Data))
+			required = append(required, "data")
+		}
+		if op.Message {
+			props["message"] = Schema{"type": "string", "description": "Localized message"}
+			required = append(required, "message")
+		}
+		for _, name := range op.Extra {
+			props[name] = Schema{"type": "string"}
+			required = append(required, name)
+		}
+		schema := Schema{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		out["content"] = Schema{fiber.MIMEApplicationJSON: Schema{"schema": schema}}
+	}
+	return out
+}
+
+func (p Param) build() Schema {
+	typ := p.Type
+	if typ == "" {
+		typ = "string"
+	}
+	schema := Schema{"type": typ}
+	if len(p.Enum) > 0 {
+		schema["enum"] = p.Enum
+	}
+	out := Schema{"name": p.Name, "in": "query", "schema": schema}
+	if p.Description != "" {
+		out["description"] = p.Description
+	}
+	return out
+}
+
+// operationID, method ve path'ten tekil bir kimlik üretir: PUT /blog/:title/approve -> putBlogTitleApprove
+func operationID(op Operation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(op.Method))
+	for _, part := range strings.FieldsFunc(strings.TrimPrefix(op.Path, "/api/v1"), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func tags(ops []Operation) []Schema {
+	seen := map[string]bool{}
+	var out []Schema
+	for _, op := range ops {
+		if !seen[op.Tag] {
+			seen[op.Tag] = true
+			out = append(out, Schema{"name": op.Tag})
+		}
+	}
+	return out
+}
+
+// Missing, uygulamada kayıtlı olup dokümanda bulunmayan route'ları "METHOD /path" olarak döner.
+// Fiber'ın GET'ler için otomatik eklediği HEAD route'ları sayılmaz.
+func Missing(routes []fiber.Route, ops []Operation) []string {
+	documented := map[string]bool{}
+	for _, op := range ops {
+		documented[op.Method+" "+Path(op.Path)] = true
+	}
+
+	seen := map[string]bool{}
+	var missing []string
+	for _, r := range routes {
+		if r.Method == fiber.MethodHead {
+			continue
+		}
+		key := r.Method + " " + Path(r.Path)
+		if !documented[key] && !seen[key] {
+			seen[key] = true
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,280 @@
+// Package render, blog gövdesini (markdown, html, plain) güvenli HTML'e çevirir
+// ve özet, kelime sayısı, okuma süresi ile içindekiler gibi türetilmiş alanları hesaplar.
+package render
+
+import (
+	"bytes"
+	"cleanArch_with_postgres/internal/entity"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	xhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	excerptLen     = 280
+	wordsPerMinute = 200
+	// TOC'a h1-h3 alınır; daha derin başlıklar listeyi kalabalıklaştırıyor
+	tocMaxLevel = 3
+)
+
+var ErrUnknownFormat = errors.New("invalid body format, expected markdown|html|plain")
+
+type Result struct {
+	HTML        string
+	Excerpt     string
+	WordCount   int
+	ReadingTime int // dakika
+	TOC         entity.TOC
+}
+
+var (
+	md = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		// ham HTML'e izin veriliyor, çıktı zaten sanitize ediliyor
+		goldmark.WithRendererOptions(gmhtml.WithUnsafe()),
+	)
+	policy     = newPolicy()
+	blankLines = regexp.MustCompile(`\n{2,}`)
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// NormalizeFormat, boş formatı plain kabul eder
+func NormalizeFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "":
+		return entity.BodyFormatPlain, nil
+	case entity.BodyFormatMarkdown, entity.BodyFormatHTML, entity.BodyFormatPlain:
+		return format, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+func Render(format, body string) (*Result, error) {
+	format, err := NormalizeFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw string
+	switch format {
+	case entity.BodyFormatMarkdown:
+		var buf bytes.Buffer
+		if err := md.Convert([]byte(body), &buf); err != nil {
+			return nil, fmt.Errorf("markdown render: %w", err)
+		}
+		raw = buf.String()
+	case entity.BodyFormatHTML:
+		raw = body
+	default:
+		raw = plainToHTML(body)
+	}
+
+	return postProcess(policy.Sanitize(raw))
+}
+
+// Apply, Content'in türetilmiş alanlarını Body ve BodyFormat'tan yeniden hesaplar
+func Apply(c *entity.Content) error {
+	format, err := NormalizeFormat(c.BodyFormat)
+	if err != nil {
+		return err
+	}
+	res, err := Render(format, c.Body)
+	if err != nil {
+		return err
+	}
+	c.BodyFormat = format
+	c.BodyHTML = res.HTML
+	c.Excerpt = res.Excerpt
+	c.WordCount = res.WordCount
+	c.ReadingTime = res.ReadingTime
+	c.TOC = res.TOC
+	return nil
+}
+
+// plainToHTML: boş satırlar paragraf, tek satır sonları <br>
+func plainToHTML(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	var b strings.Builder
+	for _, para := range blankLines.Split(body, -1) {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(para), "\n", "<br>"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// postProcess, başlıklara id verir, TOC'u ve düz metni çıkarır
+func postProcess(sanitized string) (*Result, error) {
+	root := &xhtml.Node{Type: xhtml.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := xhtml.ParseFragment(strings.NewReader(sanitized), root)
+	if err != nil {
+		return nil, fmt.Errorf("html parse: %w", err)
+	}
+
+	res := &Result{TOC: entity.TOC{}}
+	usedIDs := map[string]int{}
+	var text strings.Builder
+
+	var walk func(n *xhtml.Node)
+	walk = func(n *xhtml.Node) {
+		if n.Type == xhtml.TextNode {
+			text.WriteString(n.Data)
+			return
+		}
+		block := n.Type == xhtml.ElementNode && isBlock(n.DataAtom)
+		if level := headingLevel(n.DataAtom); n.Type == xhtml.ElementNode && level > 0 {
+			title := strings.Join(strings.Fields(nodeText(n)), " ")
+			id := uniqueID(headingID(title), usedIDs)
+			setAttr(n, "id", id)
+			if level <= tocMaxLevel && title != "" {
+				res.TOC = append(res.TOC, entity.TOCEntry{Level: level, ID: id, Text: title})
+			}
+		}
+		if block {
+			text.WriteString(" ")
+		}
+		for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+			walk(ch)
+		}
+		if block {
+			text.WriteString(" ")
+		}
+	}
+
+	var out bytes.Buffer
+	for _, n := range nodes {
+		walk(n)
+		if err := xhtml.Render(&out, n); err != nil {
+			return nil, fmt.Errorf("html render: %w", err)
+		}
+	}
+
+	words := strings.Fields(text.String())
+	res.HTML = out.String()
+	res.WordCount = len(words)
+	res.Excerpt = excerpt(words)
+	if res.WordCount > 0 {
+		res.ReadingTime = (res.WordCount + wordsPerMinute - 1) / wordsPerMinute
+	}
+	return res, nil
+}
+
+// excerpt, kelime ortasından kesmeden ilk excerptLen karakteri alır
+func excerpt(words []string) string {
+	var b strings.Builder
+	n := 0
+	for _, w := range words {
+		wl := utf8.RuneCountInString(w)
+		if n > 0 && n+1+wl > excerptLen {
+			return b.String() + "…"
+		}
+		if n > 0 {
+			b.WriteByte(' ')
+			n++
+		}
+		b.WriteString(w)
+		n += wl
+	}
+	return b.String()
+}
+
+func headingLevel(a atom.Atom) int {
+	switch a {
+	case atom.H1:
+		return 1
+	case atom.H2:
+		return 2
+	case atom.H3:
+		return 3
+	case atom.H4:
+		return 4
+	case atom.H5:
+		return 5
+	case atom.H6:
+		return 6
+	}
+	return 0
+}
+
+func isBlock(a atom.Atom) bool {
+	switch a {
+	case atom.P, atom.Div, atom.Br, atom.Li, atom.Ul, atom.Ol, atom.Pre, atom.Blockquote,
+		atom.Table, atom.Tr, atom.Td, atom.Th, atom.Hr,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		return true
+	}
+	return false
+}
+
+func nodeText(n *xhtml.Node) string {
+	if n.Type == xhtml.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		b.WriteString(nodeText(ch))
+	}
+	return b.String()
+}
+
+func setAttr(n *xhtml.Node, key, val string) {
+	for i := range n.Attr {
+		if n.Attr[i].Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, xhtml.Attribute{Key: key, Val: val})
+}
+
+// headingID: küçük harf, harf/rakam dışındaki karakter grupları tek tire
+func headingID(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	id := strings.TrimRight(b.String(), "-")
+	if id == "" {
+		id = "section"
+	}
+	return id
+}
+
+func uniqueID(id string, used map[string]int) string {
+	n := used[id]
+	used[id] = n + 1
+	if n == 0 {
+		return id
+	}
+	return fmt.Sprintf("%s-%d", id, n)
+}
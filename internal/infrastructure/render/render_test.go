@@ -0,0 +1,68 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSanitizesXSS(t *testing.T) {
+	tests := []struct {
+		name, format, body string
+		forbidden          []string // çıktıda bulunmamalı (küçük harfe çevrilmiş)
+	}{
+		{"script tag", "html", `<p>hi</p><script>alert(1)</script>`, []string{"<script", "alert(1)"}},
+		{"event handler", "html", `<img src="x.png" onerror="alert(1)">`, []string{"onerror"}},
+		{"javascript link", "html", `<a href="javascript:alert(1)">x</a>`, []string{"javascript:"}},
+		{"mixed case scheme", "html", `<a href="JaVaScRiPt:alert(1)">x</a>`, []string{"javascript:"}},
+		{"data uri", "html", `<a href="data:text/html;base64,PHNjcmlwdD4=">x</a>`, []string{"data:text/html"}},
+		{"iframe", "html", `<iframe src="https://evil.example"></iframe>`, []string{"<iframe"}},
+		{"style attribute", "html", `<p style="background:url(javascript:alert(1))">x</p>`, []string{"style=", "javascript:"}},
+		{"svg onload", "html", `<svg onload="alert(1)"></svg>`, []string{"<svg", "onload"}},
+		{"markdown raw html", "markdown", "# T\n\n<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>", []string{"<script", "onerror"}},
+		{"markdown javascript link", "markdown", "[x](javascript:alert(1))", []string{"javascript:"}},
+		{"code class injection", "html", `<code class="language-go x" onclick="a()">x</code>`, []string{"onclick", `class="language-go x"`}},
+		{"plain text is escaped", "plain", `<script>alert(1)</script>`, []string{"<script"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Render(tt.format, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := strings.ToLower(res.HTML)
+			for _, f := range tt.forbidden {
+				if strings.Contains(out, strings.ToLower(f)) {
+					t.Errorf("output contains %q: %s", f, res.HTML)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderKeepsSafeMarkup(t *testing.T) {
+	tests := []struct {
+		name, format, body, want string
+	}{
+		{"external link", "html", `<a href="https://example.com">x</a>`, `rel="nofollow noopener"`},
+		{"code language", "markdown", "```go\nx := 1\n```", `class="language-go"`},
+		{"heading id", "markdown", "## Giriş Bölümü", `id="giriş-bölümü"`},
+		{"plain paragraphs", "plain", "a\nb\n\nc", "<p>a<br/>b</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Render(tt.format, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(res.HTML, tt.want) {
+				t.Errorf("output %q does not contain %q", res.HTML, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	if _, err := Render("rst", "x"); err != ErrUnknownFormat {
+		t.Errorf("err = %v, want ErrUnknownFormat", err)
+	}
+}
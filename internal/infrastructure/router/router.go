@@ -3,9 +3,21 @@ package router
 import (
 	"cleanArch_with_postgres/internal/handler"
 	"cleanArch_with_postgres/internal/infrastructure/app"
+	"cleanArch_with_postgres/internal/infrastructure/buildinfo"
+	"cleanArch_with_postgres/internal/infrastructure/database"
+	"cleanArch_with_postgres/internal/infrastructure/openapi"
 	"cleanArch_with_postgres/internal/middleware"
 	"cleanArch_with_postgres/internal/repository"
 	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
 )
 
 type Router struct{}
@@ -14,30 +26,137 @@ func NewRouter() *Router {
 	return &Router{}
 }
 
-func (Router) RegisterRouter(a *app.App) {
+func (Router) RegisterRouter(a *app.App) error {
 
 	app := a.FiberApp
 	db := a.DB
+	bus := a.Bus
 
 	// Repositories
 	ur := repository.NewUserRepository(db)
 	br := repository.NewBlogRepository(db)
 	rr := repository.NewRoleRequestRepository(db)
+	nr := repository.NewNotificationRepository(db)
+	wr := repository.NewWebhookRepository(db)
+	rcr := repository.NewReactionRepository(db)
+	bmr := repository.NewBookmarkRepository(db)
+	anr := repository.NewAnalyticsRepository(db)
+	tr := repository.NewTrendingRepository(db)
+	mr := repository.NewMediaRepository(db)
+	ir := repository.NewImportRepository(db)
+	pr := repository.NewPrivacyRepository(db)
+	rtr := repository.NewRetentionRepository(db)
 
 	// Services
-	as := service.NewAuthService(ur, br, rr)
-	bs := service.NewBlogService(br, ur)
+	ns := service.NewNotificationService(nr, ur, bus)
+	ws := service.NewWebhookService(wr, a.Cfg.Webhook)
+	as := service.NewAuthService(ur, br, rr, ns, ws)
+	ms := service.NewMediaService(mr, ur, a.Storage, a.Cfg.Media)
+	bs := service.NewBlogService(br, ur, ns, ws, ms)
+	rcs := service.NewReactionService(rcr, br, ur, a.Cfg.Reaction)
+	bms := service.NewBookmarkService(bmr, br, ur)
+	ans := service.NewAnalyticsService(anr, br, ur, a.Cfg.Analytics)
+	ts := service.NewTrendingService(tr, a.Cfg.Trending)
+	fs := service.NewFeedService(br, ur, a.Cfg.Site)
+	sms := service.NewSitemapService(br, a.Cfg.Site)
+	ars := service.NewArchiveService(bs, br, ur)
+	is := service.NewImportService(ir, ur, br)
+	ps := service.NewPrivacyService(pr, ur, br, ns, a.Cfg.Privacy)
+	rts := service.NewRetentionService(rtr, a.Cfg.Retention)
 
 	// Handlers
 	ah := handler.NewAuthHandler(as)
-	bh := handler.NewBlogHandler(bs)
+	bh := handler.NewBlogHandler(bs, ans, ts)
+	nh := handler.NewNotificationHandler(ns, bus, a.Done())
+	wh := handler.NewWebhookHandler(ws)
+	rch := handler.NewReactionHandler(rcs)
+	bmh := handler.NewBookmarkHandler(bms)
+	anh := handler.NewAnalyticsHandler(ans)
+	fh := handler.NewFeedHandler(fs)
+	smh := handler.NewSitemapHandler(sms)
+	mh := handler.NewMediaHandler(ms, a.Cfg.Media.MaxSize)
+	arh := handler.NewArchiveHandler(ars)
+	ih := handler.NewImportHandler(is)
+	ph := handler.NewPrivacyHandler(ps)
+	rth := handler.NewRetentionHandler(rts)
+
+	spec, err := openapi.JSON(a.Cfg.Site.Title+" API", "v1", openapi.Operations)
+	if err != nil {
+		return fmt.Errorf("openapi: %w", err)
+	}
+	dh := handler.NewDocsHandler(spec)
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return fmt.Errorf("migrations: %w", err)
+	}
+	bi := buildinfo.Get()
+	hh := handler.NewHealthHandler(viewmodel.VersionVM{
+		Commit:    bi.Commit,
+		BuildTime: bi.BuildTime,
+		GoVersion: bi.GoVersion,
+		Modified:  bi.Modified,
+	},
+		migrator.Current,
+		handler.HealthCheck{Name: "shutdown", Check: func(context.Context) error {
+			if a.Draining() {
+				return errors.New("shutting down")
+			}
+			return nil
+		}},
+		handler.HealthCheck{Name: "database", Check: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+		handler.HealthCheck{Name: "migrations", Check: migrator.Check},
+		handler.HealthCheck{Name: "workers", Check: func(context.Context) error { return a.Workers.Healthy() }},
+	)
+
+	// Background jobs
+	a.Workers.Every("webhook-deliveries", a.Cfg.Webhook.PollInterval, ws.ProcessDue)
+	a.Workers.Every("trending-scores", a.Cfg.Trending.Interval, ts.Refresh)
+	a.Workers.Every("blog-render-backfill", time.Minute, bs.RenderPending)
+	a.Workers.Every("media-gc", a.Cfg.Media.GCInterval, ms.CollectGarbage)
+	a.Workers.Every("user-erasure", a.Cfg.Privacy.ErasureInterval, ps.ProcessDueErasures)
+	a.Workers.Every("retention-purge", a.Cfg.Retention.Interval, rts.Purge)
+
+	// Probe'lar (public, orchestrator JWT göndermez)
+	app.Get("/healthz", hh.Live)
+	app.Get("/readyz", hh.Ready)
+	app.Get("/version", hh.Version)
+
+	// Feeds (public, feed okuyucular JWT gönderemez)
+	app.Get("/feeds", fh.SiteFeed)
+	app.Get("/feeds/user/:username", fh.AuthorFeed)
+	app.Get("/feeds/tag/:tag", fh.TagFeed)
+	app.Get("/feeds/category/:category", fh.CategoryFeed)
+
+	// Local storage dosyaları (s3 driver'da dosyalar doğrudan bucket'tan sunulur)
+	if a.Cfg.Media.Driver == "" || a.Cfg.Media.Driver == "local" {
+		app.Static("/media/files", a.Cfg.Media.LocalDir, fiber.Static{MaxAge: 31536000})
+	}
+
+	// Sitemap (public)
+	app.Get("/sitemap.xml", smh.Sitemap)
+	app.Get("/sitemap-:page.xml", smh.SitemapPage)
 
 	v1 := app.Group("/api/v1")
 
+	// API dokümanı (public)
+	v1.Get("/openapi.json", dh.Spec)
+	v1.Get("/docs", dh.UI)
+
 	v1.Post("/register", ah.Register)
 	v1.Post("/login", ah.Login)
 
+	// SSE: EventSource header gönderemediği için token ?access_token= ile de kabul edilir
+	v1.Get("/events/stream", middleware.TokenFromQuery(), middleware.JWTMiddleware(), nh.Stream)
+
 	v1.Use(middleware.JWTMiddleware())
+	v1.Use(middleware.PasswordResetGuard("/api/v1/me"))
 
 	// Auth
 	v1.Get("/users", ah.SearchUsers) // autocomplete (unpublic)
@@ -45,13 +164,23 @@ func (Router) RegisterRouter(a *app.App) {
 	v1.Put("/user/:username", ah.UpdateUser)
 	v1.Delete("/user/:username", ah.DeleteUser)
 	v1.Put("/user/:username/restore", ah.RestoreUser)
+	v1.Get("/user/:username/reading-lists", bmh.ListPublicReadingLists)
+	v1.Get("/user/:username/reading-lists/:slug", bmh.GetPublicReadingList)
 	// Me
 	v1.Get("/me", ah.GetMe)
 	v1.Put("/me", ah.UpdateMe)
 	v1.Delete("/me", ah.DeleteMe)
+	v1.Get("/me/liked", rch.ListLiked)
+	v1.Get("/me/blogs/export", arh.ExportBlogs)  // zip, markdown + YAML front matter
+	v1.Post("/me/blogs/import", arh.ImportBlogs) // multipart: archive
+	v1.Get("/me/data-export", ph.ExportData)     // kişisel veriler, JSON
+	v1.Get("/me/erasure", ph.GetErasure)
+	v1.Post("/me/erasure", ph.RequestErasure) // body: {"password": "..."}
+	v1.Delete("/me/erasure", ph.CancelErasure)
 
 	// Blog
 	v1.Get("/blogs", bh.GetAllBlogs)
+	v1.Get("/blogs/trending", bh.GetTrending) // /blogs/:username'den önce olmalı
 	v1.Get("/blogs/:username", bh.GetBlogsByAuthor)
 	v1.Get("/blogs-deleted/:username", bh.GetBlogsByAuthorIncludeDeleted)
 	v1.Get("/blog/:title", bh.GetBlogByTitle)
@@ -62,9 +191,61 @@ func (Router) RegisterRouter(a *app.App) {
 	v1.Put("/blog/:title/unapprove", bh.UnapproveBlog)
 	v1.Put("/blog/:title/restore", bh.RestoreBlog)
 
+	// Reactions
+	v1.Get("/reactions/types", rch.ListTypes)
+	v1.Post("/blog/:title/reactions", rch.React) // body: {"type": "like"}
+	v1.Delete("/blog/:title/reactions/:type", rch.Unreact)
+
+	// Media
+	v1.Get("/media", mh.List)
+	v1.Post("/media", mh.Upload) // multipart: file
+	v1.Delete("/media/:id", mh.Delete)
+
+	// Bookmarks & reading lists
+	v1.Get("/bookmarks", bmh.ListBookmarks) // ?list_id=
+	v1.Post("/bookmarks", bmh.CreateBookmark)
+	v1.Put("/bookmarks/:id", bmh.UpdateBookmark)
+	v1.Delete("/bookmarks/:id", bmh.DeleteBookmark)
+	v1.Get("/reading-lists", bmh.ListMyReadingLists)
+	v1.Post("/reading-lists", bmh.CreateReadingList)
+	v1.Get("/reading-lists/:id", bmh.GetReadingList)
+	v1.Put("/reading-lists/:id", bmh.UpdateReadingList)
+	v1.Delete("/reading-lists/:id", bmh.DeleteReadingList)
+
+	// Analytics
+	v1.Get("/analytics/me", anh.AuthorReport) // ?from=YYYY-MM-DD&to=YYYY-MM-DD
+	v1.Get("/analytics", anh.SiteReport)      // admin, ?author=&from=&to=
+
 	// Role Requests
 	v1.Get("/role-requests", ah.ListRoleRequests) // ?status=pending|approved|rejected&limit=100
 	v1.Post("/role-requests", ah.RequestAdminRole)
 	v1.Put("/role-requests/:id/approve", ah.ApproveRoleRequest)
 	v1.Put("/role-requests/:id/reject", ah.RejectRoleRequest)
+
+	// Notifications
+	v1.Get("/notifications", nh.ListNotifications) // ?unread=true&limit=50
+	v1.Put("/notifications/read-all", nh.MarkAllRead)
+	v1.Put("/notifications/:id/read", nh.MarkRead)
+
+	// Retention (admin)
+	v1.Get("/retention/preview", rth.Preview) // dry-run
+
+	// Import (admin)
+	v1.Post("/import/wxr", ih.ImportWXR) // multipart: file
+
+	// Webhooks (admin)
+	v1.Get("/webhooks", wh.ListWebhooks)
+	v1.Post("/webhooks", wh.CreateWebhook)
+	v1.Get("/webhooks/:id", wh.GetWebhook)
+	v1.Put("/webhooks/:id", wh.UpdateWebhook)
+	v1.Delete("/webhooks/:id", wh.DeleteWebhook)
+	v1.Get("/webhooks/:id/deliveries", wh.ListDeliveries) // ?status=pending|succeeded|failed&limit=100
+	v1.Get("/webhook-deliveries/:id", wh.GetDelivery)
+	v1.Post("/webhook-deliveries/:id/replay", wh.ReplayDelivery)
+
+	// Dokümanda olmayan route'lar açılışta loglanır (bkz. `openapi check` komutu)
+	if missing := openapi.Missing(app.GetRoutes(true), openapi.Operations); len(missing) > 0 {
+		slog.Warn("routes missing from openapi spec", "routes", strings.Join(missing, ", "))
+	}
+	return nil
 }
@@ -0,0 +1,45 @@
+package router
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/app"
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/infrastructure/eventbus"
+	"cleanArch_with_postgres/internal/infrastructure/openapi"
+	"cleanArch_with_postgres/internal/infrastructure/storage"
+	"cleanArch_with_postgres/internal/infrastructure/worker"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Router DB'siz kurulur, handler'lar çalışmaz; sadece kayıtlı route'lar dokümanla karşılaştırılır
+func TestAllRoutesDocumented(t *testing.T) {
+	cfg, err := config.Setup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Media.Driver = "local"
+	cfg.Media.LocalDir = t.TempDir()
+	store, err := storage.New(cfg.Media)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &app.App{
+		FiberApp: fiber.New(),
+		Cfg:      cfg,
+		Bus:      eventbus.NewMemoryBus(),
+		Storage:  store,
+		Workers:  worker.NewRunner(),
+	}
+	if err := NewRouter().RegisterRouter(a); err != nil {
+		t.Fatal(err)
+	}
+
+	routes := a.FiberApp.GetRoutes(true)
+	if len(routes) == 0 {
+		t.Fatal("no routes registered")
+	}
+	if missing := openapi.Missing(routes, openapi.Operations); len(missing) > 0 {
+		t.Errorf("routes missing from openapi.Operations:\n%v", missing)
+	}
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrNotFound = errors.New("object not found")
+
+// Storage, medya dosyalarının yazıldığı yer; key'ler "/" ile ayrılmış göreli yollardır
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL, dosyanın herkese açık adresi
+	URL(key string) string
+}
+
+func New(cfg config.MediaConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg.LocalDir, cfg.PublicURL)
+	case "s3":
+		return NewS3Storage(cfg.S3, cfg.PublicURL)
+	default:
+		return nil, fmt.Errorf("unknown media driver: %q", cfg.Driver)
+	}
+}
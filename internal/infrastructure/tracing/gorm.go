@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "tracing:span"
+
+var tracer = otel.Tracer("cleanArch_with_postgres/gorm")
+
+// RegisterDB, her GORM sorgusu için isteğin span'ine bağlı bir client span açar. Sorgu metni
+// parametresiz (?, $1) yazılır, değerler span'e girmez.
+func RegisterDB(db *gorm.DB) error {
+	return db.Use(gormPlugin{})
+}
+
+type gormPlugin struct{}
+
+func (gormPlugin) Name() string { return "tracing" }
+
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", before("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", after("create")),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", before("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", after("query")),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", before("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", before("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", before("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", before("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", after("raw")),
+	)
+}
+
+// querySpan, sorgu bitince span'i kapatıp statement'ın context'ini eski haline getirmek için saklanır
+type querySpan struct {
+	span   trace.Span
+	parent context.Context
+}
+
+func before(op string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		parent := db.Statement.Context
+		ctx, span := tracer.Start(parent, "db."+op,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBOperationName(op)),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(spanKey, querySpan{span: span, parent: parent})
+	}
+}
+
+func after(op string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(spanKey)
+		if !ok {
+			return
+		}
+		qs := v.(querySpan)
+		defer qs.span.End()
+		db.Statement.Context = qs.parent
+
+		if table := db.Statement.Table; table != "" {
+			qs.span.SetName("db." + op + " " + table)
+			qs.span.SetAttributes(semconv.DBCollectionName(table))
+		}
+		qs.span.SetAttributes(semconv.DBQueryText(db.Statement.SQL.String()))
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			qs.span.RecordError(db.Error)
+			qs.span.SetStatus(codes.Error, db.Error.Error())
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Art arda bu kadar başarısız çalışan iş sağlıksız sayılır (/readyz)
+const maxFailures = 3
+
+type job struct {
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context) error
+
+	mu       sync.Mutex
+	lastRun  time.Time
+	lastErr  error
+	failures int
+}
+
+// JobStatus, bir işin son çalışma durumu
+type JobStatus struct {
+	Name     string
+	LastRun  time.Time
+	LastErr  error
+	Failures int // art arda başarısız çalışma sayısı
+}
+
+// Runner, arka planda periyodik çalışan işleri (webhook teslimi vb.) yönetir
+type Runner struct {
+	jobs    []*job
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running atomic.Bool
+}
+
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Every, Start'tan önce çağrılmalı
+func (r *Runner) Every(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	r.jobs = append(r.jobs, &job{name: name, interval: interval, fn: fn})
+}
+
+func (r *Runner) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.running.Store(true)
+
+	for _, j := range r.jobs {
+		r.wg.Add(1)
+		go func(j *job) {
+			defer r.wg.Done()
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+			for {
+				err := j.run(ctx)
+				if err != nil && ctx.Err() == nil {
+					slog.ErrorContext(ctx, "worker error", "job", j.name, "err", err)
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(j)
+	}
+}
+
+// run, işi bir kez çalıştırır; panic goroutine'i öldürmez, hata olarak kaydedilir
+func (j *job) run(ctx context.Context) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		j.lastRun = time.Now()
+		j.lastErr = err
+		if err != nil {
+			j.failures++
+		} else {
+			j.failures = 0
+		}
+	}()
+	return j.fn(ctx)
+}
+
+// Stop, çalışan işlerin bitmesini bekler
+func (r *Runner) Stop() {
+	if r.cancel == nil {
+		return
+	}
+	r.running.Store(false)
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *Runner) Status() []JobStatus {
+	out := make([]JobStatus, len(r.jobs))
+	for i, j := range r.jobs {
+		j.mu.Lock()
+		out[i] = JobStatus{Name: j.name, LastRun: j.lastRun, LastErr: j.lastErr, Failures: j.failures}
+		j.mu.Unlock()
+	}
+	return out
+}
+
+// Healthy, runner çalışmıyorsa ya da bir iş art arda maxFailures kez başarısız olduysa hata döner
+func (r *Runner) Healthy() error {
+	if !r.running.Load() {
+		return fmt.Errorf("workers are not running")
+	}
+	for _, s := range r.Status() {
+		if s.Failures >= maxFailures {
+			return fmt.Errorf("job %s failed %d times in a row: %v", s.Name, s.Failures, s.LastErr)
+		}
+	}
+	return nil
+}
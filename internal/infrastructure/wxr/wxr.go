@@ -0,0 +1,199 @@
+// Package wxr, WordPress eXtended RSS (WXR) export dosyalarını akış halinde okur.
+// Büyük export'lar belleğe alınmadan eleman eleman işlenir.
+package wxr
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// wp:* elemanlarının namespace'i sürüme göre değişiyor (1.0, 1.1, 1.2)
+const wpNamespacePrefix = "http://wordpress.org/export/"
+
+const dateLayout = "2006-01-02 15:04:05"
+
+var ErrNotWXR = errors.New("file is not a WordPress WXR export")
+
+type Site struct {
+	Title string
+	Link  string
+}
+
+type Author struct {
+	ID          string `xml:"author_id"`
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+type Category struct {
+	Nicename string `xml:"category_nicename"`
+	Name     string `xml:"cat_name"`
+}
+
+type Tag struct {
+	Slug string `xml:"tag_slug"`
+	Name string `xml:"tag_name"`
+}
+
+// Term, item içindeki <category domain="category|post_tag">
+type Term struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type Comment struct {
+	ID          string `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	UserID      string `xml:"comment_user_id"`
+}
+
+type Item struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Creator     string    `xml:"creator"`
+	Content     string    `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PostID      string    `xml:"post_id"`
+	PostDate    string    `xml:"post_date"`
+	PostDateGMT string    `xml:"post_date_gmt"`
+	ModifiedGMT string    `xml:"post_modified_gmt"`
+	PostType    string    `xml:"post_type"`
+	Status      string    `xml:"status"`
+	Terms       []Term    `xml:"category"`
+	Comments    []Comment `xml:"comment"`
+}
+
+func (it *Item) Categories() []string { return it.terms("category") }
+func (it *Item) Tags() []string       { return it.terms("post_tag") }
+
+func (it *Item) terms(domain string) []string {
+	var out []string
+	for _, t := range it.Terms {
+		if t.Domain == domain && strings.TrimSpace(t.Name) != "" {
+			out = append(out, strings.TrimSpace(t.Name))
+		}
+	}
+	return out
+}
+
+// Published, GMT tarih yoksa (taslaklarda 0000-00-00) yerel tarihi kullanır
+func (it *Item) Published() time.Time {
+	if t, ok := ParseDate(it.PostDateGMT); ok {
+		return t
+	}
+	t, _ := ParseDate(it.PostDate)
+	return t
+}
+
+func (it *Item) Modified() time.Time {
+	if t, ok := ParseDate(it.ModifiedGMT); ok {
+		return t
+	}
+	return it.Published()
+}
+
+func ParseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasPrefix(s, "0000") {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(dateLayout, s, time.UTC)
+	return t, err == nil
+}
+
+// Visitor'a sırasıyla *Site (bir kez, ilk içerikten önce), *Author, *Category, *Tag ve *Item gelir.
+// Visitor hata dönerse okuma durur.
+type Visitor func(v interface{}) error
+
+func Decode(r io.Reader, visit Visitor) error {
+	d := xml.NewDecoder(r)
+	site := &Site{}
+	siteSent := false
+	sendSite := func() error {
+		if siteSent {
+			return nil
+		}
+		siteSent = true
+		return visit(site)
+	}
+
+	var stack []string
+	sawChannel := false
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && t.Name.Local != "rss" {
+				return ErrNotWXR
+			}
+			if len(stack) == 1 && t.Name.Local == "channel" {
+				sawChannel = true
+			}
+			// rss > channel > X
+			if len(stack) == 2 && stack[1] == "channel" {
+				v, err := decodeChannelChild(d, t, site)
+				if err != nil {
+					return err
+				}
+				if v != nil {
+					if err := sendSite(); err != nil {
+						return err
+					}
+					if err := visit(v); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			stack = append(stack, t.Name.Local)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	if !sawChannel {
+		return ErrNotWXR
+	}
+	return sendSite()
+}
+
+// decodeChannelChild, channel'ın doğrudan alt elemanını tüketir; ilgilenilmeyenler atlanır
+func decodeChannelChild(d *xml.Decoder, start xml.StartElement, site *Site) (interface{}, error) {
+	isWP := strings.HasPrefix(start.Name.Space, wpNamespacePrefix)
+	switch {
+	case start.Name.Local == "title" && start.Name.Space == "":
+		return nil, d.DecodeElement(&site.Title, &start)
+	case start.Name.Local == "link" && start.Name.Space == "":
+		return nil, d.DecodeElement(&site.Link, &start)
+	case start.Name.Local == "item":
+		var it Item
+		return &it, d.DecodeElement(&it, &start)
+	case isWP && start.Name.Local == "author":
+		var a Author
+		return &a, d.DecodeElement(&a, &start)
+	case isWP && start.Name.Local == "category":
+		var c Category
+		return &c, d.DecodeElement(&c, &start)
+	case isWP && start.Name.Local == "tag":
+		var t Tag
+		return &t, d.DecodeElement(&t, &start)
+	}
+	return nil, d.Skip()
+}
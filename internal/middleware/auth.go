@@ -2,19 +2,25 @@ package middleware
 
 import (
 	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+	"cleanArch_with_postgres/internal/service"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+var (
+	errMissingToken  = service.Unauthorized("missing_token", "Missing or invalid authorization header")
+	errInvalidToken  = service.Unauthorized("invalid_token", "Invalid or expired token")
+	errPasswordReset = service.Forbidden("password_reset_required", "password reset required")
+)
+
 func JWTMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" || len(authHeader) < 7 || authHeader[:7] != "Bearer " {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Missing or invalid authorization header",
-			})
+			return errMissingToken
 		}
 		if strings.HasPrefix(authHeader, "Bearer ") {
 			authHeader = strings.TrimPrefix(authHeader, "Bearer ")
@@ -33,15 +39,11 @@ func JWTMiddleware() fiber.Handler {
 		})
 
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Invalid token",
-			})
+			return errInvalidToken
 		}
 
 		if token == nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Invalid or expired token",
-			})
+			return errInvalidToken
 		}
 
 		if v, ok := claims["username"].(string); ok && v != "" {
@@ -52,6 +54,15 @@ func JWTMiddleware() fiber.Handler {
 			c.Locals("role", v)
 		}
 
+		// kullanıcının dil tercihi Accept-Language'dan önce gelir
+		if v, ok := claims["locale"].(string); ok && i18n.Supported(v) {
+			setLocale(c, v)
+		}
+
+		if v, ok := claims["must_reset_password"].(bool); ok && v {
+			c.Locals("must_reset_password", true)
+		}
+
 		// user_id (MapClaims sayısal değerleri float64 getiriyo)
 		if v, ok := claims["user_id"]; ok {
 			switch id := v.(type) {
@@ -79,3 +90,30 @@ func JWTMiddleware() fiber.Handler {
 		return c.Next()
 	}
 }
+
+// PasswordResetGuard, şifresini sıfırlaması gereken kullanıcıların token'ı ile sadece
+// allowedPath'e (GET ve PUT) izin verir. JWTMiddleware'den sonra kullanılmalı.
+func PasswordResetGuard(allowedPath string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if must, _ := c.Locals("must_reset_password").(bool); !must {
+			return c.Next()
+		}
+		if c.Path() == allowedPath && (c.Method() == fiber.MethodGet || c.Method() == fiber.MethodPut) {
+			return c.Next()
+		}
+		return errPasswordReset
+	}
+}
+
+// TokenFromQuery, Authorization header'ı set edemeyen client'lar (ör. tarayıcı EventSource)
+// için ?access_token= parametresini header'a taşır. JWTMiddleware'den önce kullanılmalı.
+func TokenFromQuery() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" {
+			if t := c.Query("access_token"); t != "" {
+				c.Request().Header.Set("Authorization", "Bearer "+t)
+			}
+		}
+		return c.Next()
+	}
+}
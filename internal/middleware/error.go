@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+	"cleanArch_with_postgres/internal/service"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var kindStatus = map[service.ErrorKind]int{
+	service.KindValidation:   fiber.StatusBadRequest,
+	service.KindUnauthorized: fiber.StatusUnauthorized,
+	service.KindForbidden:    fiber.StatusForbidden,
+	service.KindNotFound:     fiber.StatusNotFound,
+	service.KindConflict:     fiber.StatusConflict,
+}
+
+// ErrorHandler, handler'lardan dönen hataları problem+json cevabına çevirir (fiber.Config.ErrorHandler).
+// Tipsiz hatalar 500 döner; detayları loglanır ama client'a yazılmaz.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	var p *viewmodel.ProblemVM
+
+	var se *service.Error
+	var fe *fiber.Error
+	switch {
+	case errors.As(err, &se):
+		status, ok := kindStatus[se.Kind]
+		if !ok {
+			status = fiber.StatusInternalServerError
+		}
+		p = viewmodel.NewProblem(status, se.Code, translate(c, se.Code, se.Message, se.Params), c.Path())
+		for _, f := range se.Fields {
+			p.Errors = append(p.Errors, viewmodel.FieldErrorVM{
+				Field:   f.Field,
+				Rule:    f.Rule,
+				Param:   f.Param,
+				Message: i18n.T(LocaleOf(c), f.Key, f.Message, map[string]string{"param": f.Param}),
+			})
+		}
+	case errors.As(err, &fe):
+		// fiber'ın kendi hataları (404 route, body limit vb.) ve handler'ların fiber.NewError'ları
+		code := statusCode(fe.Code)
+		p = viewmodel.NewProblem(fe.Code, code, translate(c, code, fe.Message, nil), c.Path())
+	default:
+		slog.ErrorContext(c.UserContext(), "unhandled error", "method", c.Method(), "path", c.Path(), "err", err)
+		p = viewmodel.NewProblem(fiber.StatusInternalServerError, "internal_error",
+			translate(c, "internal_error", "internal server error", nil), c.Path())
+	}
+
+	return c.Status(p.Status).JSON(p, viewmodel.ProblemContentType)
+}
+
+// translate, hata kodunun isteğin dilindeki mesajı; katalogda yoksa fallback
+func translate(c *fiber.Ctx, code, fallback string, params map[string]string) string {
+	return i18n.T(LocaleOf(c), "errors."+code, fallback, params)
+}
+
+// statusCode, HTTP status metninden kod üretir: 413 -> "request_entity_too_large"
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale, cevap dilini Accept-Language'dan seçip c.Locals("locale")'a yazar.
+// Giriş yapmış kullanıcının dil tercihi varsa JWTMiddleware bunu ezer.
+func Locale() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		setLocale(c, i18n.Match(c.Get(fiber.HeaderAcceptLanguage)))
+		return c.Next()
+	}
+}
+
+func setLocale(c *fiber.Ctx, locale string) {
+	c.Locals("locale", locale)
+	c.Set(fiber.HeaderContentLanguage, locale)
+	c.Vary(fiber.HeaderAcceptLanguage)
+}
+
+// LocaleOf, isteğin dili; Locale middleware'i çalışmadıysa varsayılan dil
+func LocaleOf(c *fiber.Ctx) string {
+	if l, ok := c.Locals("locale").(string); ok && l != "" {
+		return l
+	}
+	return i18n.Default()
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/metrics"
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics, istekleri route şablonuyla (/blog/:title) sayar; gerçek path etiket olarak kullanılmaz
+// ki metrik sayısı patlamasın. AccessLog'dan önce eklenmeli, hatalar orada cevaba çevrilir ve
+// status burada doğru okunur.
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		// eşleşen route yoksa son çalışan Use middleware'inin prefix'i gelir ("/", "/api/v1")
+		labels := []string{c.Method(), c.Route().Path, strconv.Itoa(c.Response().StatusCode())}
+		metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+		metrics.HTTPDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// MetricsAuth, /metrics ana port'tan sunulduğunda "Authorization: Bearer <token>" ister
+func MetricsAuth(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		got, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return errInvalidToken
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/logger"
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// Dışarıdan gelen id sadece güvenli karakterlerden oluşuyorsa kullanılır (log enjeksiyonu olmasın)
+var requestIDRe = regexp.MustCompile(`^[A-Za-z0-9._\-]{1,64}$`)
+
+// RequestID, isteğe X-Request-ID atar (gelen header geçerliyse onu kullanır), cevaba yazar ve
+// c.UserContext()'e koyar; handler'lar bu context'i servis ve repository'lere geçirir.
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(fiber.HeaderXRequestID)
+		if !requestIDRe.MatchString(id) {
+			id = utils.UUIDv4()
+		}
+		c.Set(fiber.HeaderXRequestID, id)
+		c.Locals("requestid", id)
+		c.SetUserContext(logger.WithRequestID(c.UserContext(), id))
+		return c.Next()
+	}
+}
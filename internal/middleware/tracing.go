@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("cleanArch_with_postgres/http")
+
+// Tracing, her istek için server span açar; gelen traceparent/tracestate header'ları varsa span
+// o izin devamı olur. Span c.UserContext()'e konur, servis ve GORM span'leri bunun altına açılır.
+// En dışta olmalı ki diğer middleware'lerin süresi de span'e dahil olsun.
+func Tracing() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{&c.Request().Header})
+		ctx, span := tracer.Start(ctx, c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+				semconv.URLScheme(c.Protocol()),
+				semconv.ClientAddress(c.IP()),
+				semconv.UserAgentOriginal(c.Get(fiber.HeaderUserAgent)),
+			),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		// hatalar AccessLog'da cevaba çevrildiği için status burada doğrudur
+		status := c.Response().StatusCode()
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, strconv.Itoa(status))
+		}
+		return err
+	}
+}
+
+// headerCarrier, fasthttp istek header'larını propagation.TextMapCarrier'a uyarlar
+type headerCarrier struct {
+	h *fasthttp.RequestHeader
+}
+
+var _ propagation.TextMapCarrier = headerCarrier{}
+
+func (hc headerCarrier) Get(key string) string { return string(hc.h.Peek(key)) }
+
+func (hc headerCarrier) Set(key, value string) { hc.h.Set(key, value) }
+
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, hc.h.Len())
+	hc.h.VisitAll(func(k, _ []byte) { keys = append(keys, string(k)) })
+	return keys
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BlogCount, blog bazında gruplanmış sayım sonuçları için
+type BlogCount struct {
+	BlogID uint
+	Count  int64
+}
+
+type DailyCount struct {
+	Day           time.Time
+	Views         int64
+	UniqueReaders int64
+}
+
+type AnalyticsRepository interface {
+	// RecordView: window, aynı okuyucunun tekrar sayılmadığı süre (at'ten geriye)
+	RecordView(ctx context.Context, blogID uint, viewerKey string, at time.Time, window time.Duration) error
+
+	// author boşsa tüm bloglar; from dahil, to hariç
+	ViewsByBlog(ctx context.Context, author string, from, to time.Time) ([]BlogCount, error)
+	UniqueReadersByBlog(ctx context.Context, author string, from, to time.Time) ([]BlogCount, error)
+	UniqueReaders(ctx context.Context, author string, from, to time.Time) (int64, error)
+	ReactionsByBlog(ctx context.Context, author string, from, to time.Time) ([]BlogCount, error)
+	CommentsByBlog(ctx context.Context, author string, from, to time.Time) ([]BlogCount, error)
+	Daily(ctx context.Context, author string, from, to time.Time) ([]DailyCount, error)
+}
+
+type analyticsRepository struct{ db *gorm.DB }
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// RecordView, ham kaydı ekler ve günlük sayacı artırır. Aynı okuyucu son window süresi içinde bu
+// yazıyı okuduysa hiçbir şey sayılmaz. Blog+okuyucu çifti için alınan advisory lock eşzamanlı
+// istekleri sıraya koyar; kontrol ve ekleme aynı transaction'da olduğu için ikisi birden sayılmaz.
+// Okuyucunun o günkü ilk okumasıysa unique_readers da artar.
+func (r *analyticsRepository) RecordView(ctx context.Context, blogID uint, viewerKey string, at time.Time, window time.Duration) error {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?::text), hashtext(?))", blogID, viewerKey).Error; err != nil {
+			return err
+		}
+
+		res := tx.Exec(`INSERT INTO blog_views (blog_id, viewer_key, viewed_at)
+			SELECT ?, ?, ? WHERE NOT EXISTS (
+				SELECT 1 FROM blog_views WHERE blog_id = ? AND viewer_key = ? AND viewed_at > ?
+			)`, blogID, viewerKey, at, blogID, viewerKey, at.Add(-window))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 { // son window içinde zaten sayıldı
+			return nil
+		}
+
+		// az önce eklenen kayıt hariç bugün okumuş mu
+		var seenToday int64
+		if err := tx.Model(&entity.BlogView{}).
+			Where("blog_id = ? AND viewer_key = ? AND viewed_at >= ? AND viewed_at < ?", blogID, viewerKey, day, at).
+			Count(&seenToday).Error; err != nil {
+			return err
+		}
+
+		var unique int64
+		if seenToday == 0 {
+			unique = 1
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "blog_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"views":          gorm.Expr("blog_daily_stats.views + 1"),
+				"unique_readers": gorm.Expr("blog_daily_stats.unique_readers + ?", unique),
+			}),
+		}).Create(&entity.BlogDailyStat{BlogID: blogID, Day: day, Views: 1, UniqueReaders: unique}).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "analytics recordView error", "err", err)
+		return err
+	}
+	return nil
+}
+
+// byAuthor, blogs tablosuyla join edip isteğe bağlı yazar filtresi uygular (silinmiş bloglar dahil)
+func byAuthor(q *gorm.DB, table, author string) *gorm.DB {
+	q = q.Joins("JOIN blogs ON blogs.id = " + table + ".blog_id")
+	if author != "" {
+		q = q.Where("blogs.username = ?", author)
+	}
+	return q
+}
+
+func (r *analyticsRepository) ViewsByBlog(ctx context.Context, author string, from, to time.Time) ([]BlogCount, error) {
+	var rows []BlogCount
+	q := r.db.WithContext(ctx).Table("blog_daily_stats").
+		Select("blog_daily_stats.blog_id AS blog_id, SUM(blog_daily_stats.views) AS count").
+		Where("blog_daily_stats.day >= ? AND blog_daily_stats.day < ?", from, to)
+	err := byAuthor(q, "blog_daily_stats", author).Group("blog_daily_stats.blog_id").Scan(&rows).Error
+	return rows, err
+}
+
+func (r *analyticsRepository) UniqueReadersByBlog(ctx context.Context, author string, from, to time.Time) ([]BlogCount, error) {
+	var rows []BlogCount
+	q := r.db.WithContext(ctx).Table("blog_views").
+		Select("blog_views.blog_id AS blog_id, COUNT(DISTINCT blog_views.viewer_key) AS count").
+		Where("blog_views.viewed_at >= ? AND blog_views.viewed_at < ?", from, to)
+	err := byAuthor(q, "blog_views", author).Group("blog_views.blog_id").Scan(&rows).Error
+	return rows, err
+}
+
+func (r *analyticsRepository) UniqueReaders(ctx context.Context, author string, from, to time.Time) (int64, error) {
+	var count int64
+	q := r.db.WithContext(ctx).Table("blog_views").
+		Select("COUNT(DISTINCT blog_views.viewer_key)").
+		Where("blog_views.viewed_at >= ? AND blog_views.viewed_at < ?", from, to)
+	err := byAuthor(q, "blog_views", author).Scan(&count).Error
+	return count, err
+}
+
+func (r *analyticsRepository) ReactionsByBlog(ctx context.Context, author string, from, to time.Time) ([]BlogCount, error) {
+	var rows []BlogCount
+	q := r.db.WithContext(ctx).Table("reactions").
+		Select("reactions.blog_id AS blog_id, COUNT(*) AS count").
+		Where("reactions.created_at >= ? AND reactions.created_at < ?", from, to)
+	err := byAuthor(q, "reactions", author).Group("reactions.blog_id").Scan(&rows).Error
+	return rows, err
+}
+
+func (r *analyticsRepository) CommentsByBlog(ctx context.Context, author string, from, to time.Time) ([]BlogCount, error) {
+	var rows []BlogCount
+	q := r.db.WithContext(ctx).Table("comments").
+		Select("comments.blog_id AS blog_id, COUNT(*) AS count").
+		Where("comments.deleted_at IS NULL").
+		Where("comments.created_at >= ? AND comments.created_at < ?", from, to)
+	err := byAuthor(q, "comments", author).Group("comments.blog_id").Scan(&rows).Error
+	return rows, err
+}
+
+func (r *analyticsRepository) Daily(ctx context.Context, author string, from, to time.Time) ([]DailyCount, error) {
+	var rows []DailyCount
+	q := r.db.WithContext(ctx).Table("blog_daily_stats").
+		Select("blog_daily_stats.day AS day, SUM(blog_daily_stats.views) AS views, SUM(blog_daily_stats.unique_readers) AS unique_readers").
+		Where("blog_daily_stats.day >= ? AND blog_daily_stats.day < ?", from, to)
+	err := byAuthor(q, "blog_daily_stats", author).
+		Group("blog_daily_stats.day").
+		Order("blog_daily_stats.day").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -3,8 +3,10 @@ package repository
 import (
 	"cleanArch_with_postgres/internal/entity"
 	"context"
-	"fmt"
+	"database/sql"
+	"log/slog"
 	"net/url"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -23,11 +25,32 @@ type BlogRepository interface {
 	GetBlogsByAuthor(ctx context.Context, username string) ([]entity.Blog, error)
 	GetBlogByTitleTrueApproved(ctx context.Context, title string) (*entity.Blog, error)
 	GetBlogByTitle(ctx context.Context, title string) (*entity.Blog, error)
+	GetByIDsIncludeDeleted(ctx context.Context, ids []uint) ([]entity.Blog, error)
+	ListPublished(ctx context.Context, filter PublishedFilter, limit int) ([]entity.Blog, error)
+	CountPublished(ctx context.Context) (int64, error)
+	LastChange(ctx context.Context) (time.Time, error)
+	ListUnrendered(ctx context.Context, limit int) ([]entity.Blog, error)
+	SaveRendered(ctx context.Context, id uint, content *entity.Content) error
+	ListSitemapEntries(ctx context.Context, offset, limit int) ([]SitemapEntry, error)
 	ExistBlog(ctx context.Context, body string) (bool, error)
 	SetApproval(ctx context.Context, title string, approved bool) error
 	Restore(ctx context.Context, title string) error
 }
 
+// PublishedFilter, boş alanlar filtrelenmez
+type PublishedFilter struct {
+	Username string
+	Tag      string
+	Category string
+}
+
+// SitemapEntry, sitemap için gereken hafif blog satırı
+type SitemapEntry struct {
+	ID        uint
+	Title     string
+	UpdatedAt time.Time
+}
+
 type blogRepository struct {
 	db *gorm.DB
 }
@@ -39,7 +62,7 @@ func NewBlogRepository(db *gorm.DB) BlogRepository {
 func (r *blogRepository) Create(ctx context.Context, blog *entity.Blog) error {
 	err := r.db.WithContext(ctx).Create(&blog).Error
 	if err != nil {
-		fmt.Println("blog create error:", err)
+		slog.ErrorContext(ctx, "blog create error", "err", err)
 		return err
 	}
 	return nil
@@ -48,23 +71,33 @@ func (r *blogRepository) Create(ctx context.Context, blog *entity.Blog) error {
 func (r *blogRepository) Update(ctx context.Context, title string, blog *entity.Blog) error {
 	decodedTitle, err := url.QueryUnescape(title)
 	if err != nil {
-		fmt.Println("title decode error:", err)
+		slog.ErrorContext(ctx, "title decode error", "err", err)
 		return err
 	}
 
 	err = r.db.WithContext(ctx).Model(&entity.Blog{}).Where("title = ?", decodedTitle).
 		Updates(map[string]interface{}{
-			"title":      blog.Content.Title,
-			"body":       blog.Content.Body,
-			"type":       blog.Content.Type,
-			"status":     blog.Content.Status,
-			"tags":       blog.Tags,
-			"category":   blog.Category,
-			"updated_at": time.Now(),
+			"title":                blog.Content.Title,
+			"body":                 blog.Content.Body,
+			"body_format":          blog.Content.BodyFormat,
+			"body_html":            blog.Content.BodyHTML,
+			"excerpt":              blog.Content.Excerpt,
+			"word_count":           blog.Content.WordCount,
+			"reading_time":         blog.Content.ReadingTime,
+			"toc":                  blog.Content.TOC,
+			"type":                 blog.Content.Type,
+			"status":               blog.Content.Status,
+			"tags":                 blog.Tags,
+			"category":             blog.Category,
+			"seo_meta_title":       blog.SEO.MetaTitle,
+			"seo_meta_description": blog.SEO.MetaDescription,
+			"seo_canonical_url":    blog.SEO.CanonicalURL,
+			"seo_og_image":         blog.SEO.OGImage,
+			"updated_at":           time.Now(),
 		}).Error
 
 	if err != nil {
-		fmt.Println("blog update error:", err)
+		slog.ErrorContext(ctx, "blog update error", "err", err)
 		return err
 	}
 	return nil
@@ -73,19 +106,19 @@ func (r *blogRepository) Update(ctx context.Context, title string, blog *entity.
 func (r *blogRepository) Delete(ctx context.Context, title string) (string, error) {
 	decodedTitle, err := url.QueryUnescape(title)
 	if err != nil {
-		fmt.Println("title decode error:", err)
+		slog.ErrorContext(ctx, "title decode error", "err", err)
 		return "", err
 	}
 
 	err = r.db.WithContext(ctx).Model(&entity.Blog{}).Where("title = ?", decodedTitle).
 		Updates(map[string]interface{}{
 			"deleted_at":  time.Now(),
-			"status":      "deleted", // silinen blogların statüsünü "deleted" olarak güncelleniyo
-			"is_approved": false,     // silinen blogun onayını kaldırıyo
+			"status":      entity.BlogStatusDeleted, // silinen blogların statüsünü "deleted" olarak güncelleniyo
+			"is_approved": false,                    // silinen blogun onayını kaldırıyo
 		}).Error
 
 	if err != nil {
-		fmt.Println("blog delete error:", err)
+		slog.ErrorContext(ctx, "blog delete error", "err", err)
 		return "", err
 	}
 	return decodedTitle, nil
@@ -99,7 +132,7 @@ func (r *blogRepository) UpdateAuthorUsername(ctx context.Context, oldUsername,
 			"updated_at": time.Now(),
 		}).Error
 	if err != nil {
-		fmt.Println("blog update author username error:", err)
+		slog.ErrorContext(ctx, "blog update author username error", "err", err)
 		return err
 	}
 	return nil
@@ -108,9 +141,9 @@ func (r *blogRepository) UpdateAuthorUsername(ctx context.Context, oldUsername,
 func (r *blogRepository) GetAllTrueApproved(ctx context.Context) ([]entity.Blog, error) {
 	var blogs []entity.Blog
 
-	err := r.db.WithContext(ctx).Where("is_approved = ?", true).Find(&blogs).Error
+	err := r.db.WithContext(ctx).Preload("ReactionCounts").Where("is_approved = ?", true).Find(&blogs).Error
 	if err != nil {
-		fmt.Println("blog getAllTrueApproved error:", err)
+		slog.ErrorContext(ctx, "blog getAllTrueApproved error", "err", err)
 		return nil, err
 	}
 	return blogs, nil
@@ -118,9 +151,9 @@ func (r *blogRepository) GetAllTrueApproved(ctx context.Context) ([]entity.Blog,
 
 func (r *blogRepository) GetAllIncludeDeleted(ctx context.Context) ([]entity.Blog, error) {
 	var blogs []entity.Blog
-	if err := r.db.WithContext(ctx).Unscoped(). // Unscoped() GORM’un soft delete filtrelemesini kapatır
-							Find(&blogs).Error; err != nil { // ve deleted_at dolu kayıtları da getirir.
-		fmt.Println("blog getAllIncludeDeleted error:", err)
+	if err := r.db.WithContext(ctx).Preload("ReactionCounts").Unscoped(). // Unscoped() GORM’un soft delete filtrelemesini kapatır
+										Find(&blogs).Error; err != nil { // ve deleted_at dolu kayıtları da getirir.
+		slog.ErrorContext(ctx, "blog getAllIncludeDeleted error", "err", err)
 		return nil, err
 	}
 	return blogs, nil
@@ -129,9 +162,9 @@ func (r *blogRepository) GetAllIncludeDeleted(ctx context.Context) ([]entity.Blo
 func (r *blogRepository) GetAll(ctx context.Context) ([]entity.Blog, error) {
 	var blogs []entity.Blog
 
-	err := r.db.WithContext(ctx).Find(&blogs).Error
+	err := r.db.WithContext(ctx).Preload("ReactionCounts").Find(&blogs).Error
 	if err != nil {
-		fmt.Println("blog getAll error:", err)
+		slog.ErrorContext(ctx, "blog getAll error", "err", err)
 		return nil, err
 	}
 	return blogs, nil
@@ -140,11 +173,11 @@ func (r *blogRepository) GetAll(ctx context.Context) ([]entity.Blog, error) {
 func (r *blogRepository) GetBlogsByAuthorTrueApproved(ctx context.Context, username string) ([]entity.Blog, error) {
 	var blogs []entity.Blog
 
-	err := r.db.WithContext(ctx).Where("is_approved = ?", true).
+	err := r.db.WithContext(ctx).Preload("ReactionCounts").Where("is_approved = ?", true).
 		Where("username = ?", username).Find(&blogs).Error
 
 	if err != nil {
-		fmt.Println("blog getBlogsByAuthorTrueApproved error:", err)
+		slog.ErrorContext(ctx, "blog getBlogsByAuthorTrueApproved error", "err", err)
 		return nil, err
 	}
 	return blogs, nil
@@ -152,12 +185,12 @@ func (r *blogRepository) GetBlogsByAuthorTrueApproved(ctx context.Context, usern
 
 func (r *blogRepository) GetBlogsByAuthorIncludeDeleted(ctx context.Context, username string) ([]entity.Blog, error) {
 	var blogs []entity.Blog
-	err := r.db.WithContext(ctx).
+	err := r.db.WithContext(ctx).Preload("ReactionCounts").
 		Unscoped(). // <— soft-deleted dahil
 		Where("username = ?", username).
 		Find(&blogs).Error
 	if err != nil {
-		fmt.Println("blog getBlogsByAuthorIncludeDeleted error:", err)
+		slog.ErrorContext(ctx, "blog getBlogsByAuthorIncludeDeleted error", "err", err)
 		return nil, err
 	}
 	return blogs, nil
@@ -166,9 +199,9 @@ func (r *blogRepository) GetBlogsByAuthorIncludeDeleted(ctx context.Context, use
 func (r *blogRepository) GetBlogsByAuthor(ctx context.Context, username string) ([]entity.Blog, error) {
 	var blogs []entity.Blog
 
-	err := r.db.WithContext(ctx).Where("username = ?", username).Find(&blogs).Error
+	err := r.db.WithContext(ctx).Preload("ReactionCounts").Where("username = ?", username).Find(&blogs).Error
 	if err != nil {
-		fmt.Println("blog getBlogsByAuthor error:", err)
+		slog.ErrorContext(ctx, "blog getBlogsByAuthor error", "err", err)
 		return nil, err
 	}
 	return blogs, nil
@@ -178,13 +211,13 @@ func (r *blogRepository) GetBlogByTitleTrueApproved(ctx context.Context, title s
 	var blog entity.Blog
 	decodedTitle, err := url.QueryUnescape(title)
 	if err != nil {
-		fmt.Println("title decode error:", err)
+		slog.ErrorContext(ctx, "title decode error", "err", err)
 		return nil, err
 	}
 
-	err = r.db.WithContext(ctx).Where(map[string]interface{}{"is_approved": true, "title": decodedTitle}).First(&blog).Error
+	err = r.db.WithContext(ctx).Preload("ReactionCounts").Where(map[string]interface{}{"is_approved": true, "title": decodedTitle}).First(&blog).Error
 	if err != nil {
-		fmt.Println("blog getBlogByTitleTrueApproved error:", err)
+		slog.ErrorContext(ctx, "blog getBlogByTitleTrueApproved error", "err", err)
 		return nil, err
 	}
 	return &blog, nil
@@ -194,18 +227,132 @@ func (r *blogRepository) GetBlogByTitle(ctx context.Context, title string) (*ent
 	var blog entity.Blog
 	decodedTitle, err := url.QueryUnescape(title)
 	if err != nil {
-		fmt.Println("title decode error:", err)
+		slog.ErrorContext(ctx, "title decode error", "err", err)
 		return nil, err
 	}
 
-	err = r.db.WithContext(ctx).Where("title = ?", decodedTitle).First(&blog).Error
+	err = r.db.WithContext(ctx).Preload("ReactionCounts").Where("title = ?", decodedTitle).First(&blog).Error
 	if err != nil {
-		fmt.Println("blog getBlogByTitle error:", err)
+		slog.ErrorContext(ctx, "blog getBlogByTitle error", "err", err)
 		return nil, err
 	}
 	return &blog, nil
 }
 
+func (r *blogRepository) GetByIDsIncludeDeleted(ctx context.Context, ids []uint) ([]entity.Blog, error) {
+	var blogs []entity.Blog
+	if len(ids) == 0 {
+		return blogs, nil
+	}
+	err := r.db.WithContext(ctx).Preload("ReactionCounts").Unscoped().
+		Where("id IN ?", ids).
+		Find(&blogs).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "blog getByIDsIncludeDeleted error", "err", err)
+		return nil, err
+	}
+	return blogs, nil
+}
+
+// ListPublished, herkese açık (onaylı, statüsü published, silinmemiş) blogları en yeniden başlayarak getirir
+func (r *blogRepository) ListPublished(ctx context.Context, filter PublishedFilter, limit int) ([]entity.Blog, error) {
+	var blogs []entity.Blog
+	if limit <= 0 {
+		limit = 50
+	}
+	q := r.publishedScope(ctx)
+	if filter.Username != "" {
+		q = q.Where("username = ?", filter.Username)
+	}
+	if filter.Tag != "" {
+		// tags virgülle ayrılmış tutuluyor; LIKE yerine tam eşleşme ki etiketteki % ve _ joker olmasın
+		q = q.Where("? = ANY(string_to_array(REPLACE(LOWER(tags), ' ', ''), ','))", strings.ToLower(strings.ReplaceAll(filter.Tag, " ", "")))
+	}
+	if filter.Category != "" {
+		q = q.Where("LOWER(category) = ?", strings.ToLower(filter.Category))
+	}
+	if err := q.Order("created_at DESC").Limit(limit).Find(&blogs).Error; err != nil {
+		slog.ErrorContext(ctx, "blog listPublished error", "err", err)
+		return nil, err
+	}
+	return blogs, nil
+}
+
+func (r *blogRepository) publishedScope(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&entity.Blog{}).
+		Where("is_approved = ? AND status = ?", true, entity.BlogStatusPublished)
+}
+
+// LastChange, silinmiş ve yayında olmayanlar dahil herhangi bir blogun en son değiştiği an; yayından
+// kaldırma, onay değişikliği ve silme de updated_at/deleted_at'i ilerlettiği için geriye gitmez
+func (r *blogRepository) LastChange(ctx context.Context) (time.Time, error) {
+	var last sql.NullTime
+	err := r.db.WithContext(ctx).Unscoped().Model(&entity.Blog{}).
+		Select("MAX(GREATEST(updated_at, COALESCE(deleted_at, updated_at)))").
+		Scan(&last).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "blog lastChange error", "err", err)
+		return time.Time{}, err
+	}
+	return last.Time, nil
+}
+
+func (r *blogRepository) CountPublished(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.publishedScope(ctx).Count(&count).Error; err != nil {
+		slog.ErrorContext(ctx, "blog countPublished error", "err", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListSitemapEntries, id sırasıyla sayfalanır; böylece sitemap dosyaları arası kayma olmaz
+func (r *blogRepository) ListSitemapEntries(ctx context.Context, offset, limit int) ([]SitemapEntry, error) {
+	var entries []SitemapEntry
+	err := r.publishedScope(ctx).
+		Select("id", "title", "updated_at").
+		Order("id ASC").Offset(offset).Limit(limit).
+		Scan(&entries).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "blog listSitemapEntries error", "err", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ListUnrendered, türetilmiş alanları henüz hesaplanmamış (eski) blogları getirir
+func (r *blogRepository) ListUnrendered(ctx context.Context, limit int) ([]entity.Blog, error) {
+	var blogs []entity.Blog
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("(body_html IS NULL OR body_html = '') AND body <> ''").
+		Order("id ASC").Limit(limit).
+		Find(&blogs).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "blog listUnrendered error", "err", err)
+		return nil, err
+	}
+	return blogs, nil
+}
+
+// SaveRendered, updated_at'e dokunmaz; içerik değişmedi sadece türetildi
+func (r *blogRepository) SaveRendered(ctx context.Context, id uint, content *entity.Content) error {
+	err := r.db.WithContext(ctx).Unscoped().Model(&entity.Blog{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"body_format":  content.BodyFormat,
+			"body_html":    content.BodyHTML,
+			"excerpt":      content.Excerpt,
+			"word_count":   content.WordCount,
+			"reading_time": content.ReadingTime,
+			"toc":          content.TOC,
+		}).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "blog saveRendered error", "err", err)
+		return err
+	}
+	return nil
+}
+
 func (r *blogRepository) ExistBlog(ctx context.Context, body string) (bool, error) {
 	var count int64
 
@@ -214,7 +361,7 @@ func (r *blogRepository) ExistBlog(ctx context.Context, body string) (bool, erro
 		Count(&count).Error
 
 	if err != nil {
-		fmt.Println("blog exist error:", err)
+		slog.ErrorContext(ctx, "blog exist error", "err", err)
 		return false, err
 	}
 	return count > 0, nil
@@ -233,7 +380,7 @@ func (r *blogRepository) SetApproval(ctx context.Context, title string, approved
 			"updated_at":  time.Now(),
 		}).Error
 	if err != nil {
-		fmt.Println("blog setApproval error:", err)
+		slog.ErrorContext(ctx, "blog setApproval error", "err", err)
 		return err
 	}
 	return nil
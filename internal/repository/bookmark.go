@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookmarkRepository interface {
+	// Bookmarks
+	Create(ctx context.Context, b *entity.Bookmark) (bool, error)
+	Update(ctx context.Context, b *entity.Bookmark) error
+	Delete(ctx context.Context, id, userID uint) error
+	GetByID(ctx context.Context, id uint) (*entity.Bookmark, error)
+	ListByUser(ctx context.Context, userID uint, listID *uint) ([]entity.Bookmark, error)
+	ListByReadingList(ctx context.Context, listID uint) ([]entity.Bookmark, error)
+
+	// Reading lists
+	CreateList(ctx context.Context, l *entity.ReadingList) error
+	UpdateList(ctx context.Context, l *entity.ReadingList) error
+	DeleteList(ctx context.Context, id uint) error
+	GetListByID(ctx context.Context, id uint) (*entity.ReadingList, error)
+	GetListBySlug(ctx context.Context, userID uint, slug string) (*entity.ReadingList, error)
+	ListListsByUser(ctx context.Context, userID uint, publicOnly bool) ([]entity.ReadingList, error)
+	ExistListSlug(ctx context.Context, userID uint, slug string, excludeID uint) (bool, error)
+}
+
+type bookmarkRepository struct{ db *gorm.DB }
+
+func NewBookmarkRepository(db *gorm.DB) BookmarkRepository {
+	return &bookmarkRepository{db: db}
+}
+
+// Create, aynı blog aynı listede zaten varsa false döner
+func (r *bookmarkRepository) Create(ctx context.Context, b *entity.Bookmark) (bool, error) {
+	res := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(b)
+	if res.Error != nil {
+		slog.ErrorContext(ctx, "bookmark create error", "err", res.Error)
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *bookmarkRepository) Update(ctx context.Context, b *entity.Bookmark) error {
+	err := r.db.WithContext(ctx).Model(&entity.Bookmark{}).
+		Where("id = ?", b.ID).
+		Updates(map[string]interface{}{
+			"note":            b.Note,
+			"reading_list_id": b.ReadingListID,
+			"updated_at":      time.Now(),
+		}).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "bookmark update error", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *bookmarkRepository) Delete(ctx context.Context, id, userID uint) error {
+	tx := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&entity.Bookmark{})
+	if tx.Error != nil {
+		slog.ErrorContext(ctx, "bookmark delete error", "err", tx.Error)
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *bookmarkRepository) GetByID(ctx context.Context, id uint) (*entity.Bookmark, error) {
+	var b entity.Bookmark
+	if err := r.db.WithContext(ctx).First(&b, id).Error; err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// ListByUser, listID nil ise kullanıcının tüm bookmark'larını getirir
+func (r *bookmarkRepository) ListByUser(ctx context.Context, userID uint, listID *uint) ([]entity.Bookmark, error) {
+	var rows []entity.Bookmark
+	q := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if listID != nil {
+		q = q.Where("reading_list_id = ?", *listID)
+	}
+	if err := q.Order("created_at DESC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *bookmarkRepository) ListByReadingList(ctx context.Context, listID uint) ([]entity.Bookmark, error) {
+	var rows []entity.Bookmark
+	if err := r.db.WithContext(ctx).
+		Where("reading_list_id = ?", listID).
+		Order("created_at DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *bookmarkRepository) CreateList(ctx context.Context, l *entity.ReadingList) error {
+	err := r.db.WithContext(ctx).Create(l).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "reading list create error", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *bookmarkRepository) UpdateList(ctx context.Context, l *entity.ReadingList) error {
+	err := r.db.WithContext(ctx).Model(&entity.ReadingList{}).
+		Where("id = ?", l.ID).
+		Updates(map[string]interface{}{
+			"name":        l.Name,
+			"slug":        l.Slug,
+			"description": l.Description,
+			"is_public":   l.IsPublic,
+			"updated_at":  time.Now(),
+		}).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "reading list update error", "err", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteList, listeyi ve listedeki bookmark'ları siler
+func (r *bookmarkRepository) DeleteList(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("reading_list_id = ?", id).Delete(&entity.Bookmark{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.ReadingList{}, id).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "reading list delete error", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *bookmarkRepository) GetListByID(ctx context.Context, id uint) (*entity.ReadingList, error) {
+	var l entity.ReadingList
+	if err := r.db.WithContext(ctx).First(&l, id).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *bookmarkRepository) GetListBySlug(ctx context.Context, userID uint, slug string) (*entity.ReadingList, error) {
+	var l entity.ReadingList
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND slug = ?", userID, slug).First(&l).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *bookmarkRepository) ListListsByUser(ctx context.Context, userID uint, publicOnly bool) ([]entity.ReadingList, error) {
+	var rows []entity.ReadingList
+	q := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if publicOnly {
+		q = q.Where("is_public = ?", true)
+	}
+	if err := q.Order("name").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *bookmarkRepository) ExistListSlug(ctx context.Context, userID uint, slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.ReadingList{}).
+		Where("user_id = ? AND slug = ? AND id <> ?", userID, slug, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
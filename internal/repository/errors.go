@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// IsNotFound, servislerin gorm'a bağımlı olmadan "kayıt yok" hatasını ayırt edebilmesi için
+func IsNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
+
+// IsUniqueViolation, unique index ihlalini (postgres 23505) diğer veritabanı hatalarından ayırır
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.Is(err, gorm.ErrDuplicatedKey) || (errors.As(err, &pgErr) && pgErr.Code == "23505")
+}
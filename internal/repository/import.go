@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"errors"
+	"log/slog"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImportRepository interface {
+	// FindTarget, eşleşme yoksa 0, false döner
+	FindTarget(ctx context.Context, source, site, kind, sourceID string) (uint, bool, error)
+	SaveMapping(ctx context.Context, m *entity.ImportMapping) error
+	// SaveMapping ve Create*, eşleşme zaten varsa hedefini günceller.
+	// Create* kaydı ve eşleşmesini aynı transaction'da yazar
+	CreateUser(ctx context.Context, u *entity.User, m *entity.ImportMapping) error
+	CreateBlog(ctx context.Context, b *entity.Blog, m *entity.ImportMapping) error
+	CreateComment(ctx context.Context, c *entity.Comment, m *entity.ImportMapping) error
+}
+
+type importRepository struct{ db *gorm.DB }
+
+func NewImportRepository(db *gorm.DB) ImportRepository {
+	return &importRepository{db: db}
+}
+
+func (r *importRepository) FindTarget(ctx context.Context, source, site, kind, sourceID string) (uint, bool, error) {
+	var m entity.ImportMapping
+	err := r.db.WithContext(ctx).
+		Where("source = ? AND source_site = ? AND kind = ? AND source_id = ?", source, site, kind, sourceID).
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "import findTarget error", "err", err)
+		return 0, false, err
+	}
+	return m.TargetID, true, nil
+}
+
+// upsertMapping: eşleşen kayıt silinip yeniden oluşturulduysa eski eşleşme yeni hedefe çevrilir
+var upsertMapping = clause.OnConflict{
+	Columns:   []clause.Column{{Name: "source"}, {Name: "source_site"}, {Name: "kind"}, {Name: "source_id"}},
+	DoUpdates: clause.AssignmentColumns([]string{"target_id"}),
+}
+
+func (r *importRepository) SaveMapping(ctx context.Context, m *entity.ImportMapping) error {
+	return r.db.WithContext(ctx).Clauses(upsertMapping).Create(m).Error
+}
+
+func (r *importRepository) CreateUser(ctx context.Context, u *entity.User, m *entity.ImportMapping) error {
+	return r.createMapped(ctx, u, func() uint { return u.ID }, m)
+}
+
+func (r *importRepository) CreateBlog(ctx context.Context, b *entity.Blog, m *entity.ImportMapping) error {
+	return r.createMapped(ctx, b, func() uint { return b.ID }, m)
+}
+
+func (r *importRepository) CreateComment(ctx context.Context, c *entity.Comment, m *entity.ImportMapping) error {
+	return r.createMapped(ctx, c, func() uint { return c.ID }, m)
+}
+
+func (r *importRepository) createMapped(ctx context.Context, record interface{}, id func() uint, m *entity.ImportMapping) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		m.TargetID = id()
+		return tx.Clauses(upsertMapping).Create(m).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "import create error", "err", err)
+	}
+	return err
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrQuotaExceeded, CreateWithinQuota'da yeni dosya kullanıcının kotasını aşıyorsa döner
+var ErrQuotaExceeded = errors.New("media quota exceeded")
+
+type MediaRepository interface {
+	Create(ctx context.Context, m *entity.Media) error
+	// CreateWithinQuota, kullanıcının toplam kullanımı m.Size ile quota'yı aşmıyorsa kaydı oluşturur.
+	// Aynı kullanıcının eşzamanlı yüklemeleri kullanıcı satırı kilitlenerek sıraya sokulur.
+	// Dönen değer kayıttan önceki kullanım.
+	CreateWithinQuota(ctx context.Context, m *entity.Media, quota int64) (int64, error)
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*entity.Media, error)
+	ListByUser(ctx context.Context, userID uint) ([]entity.Media, error)
+	UsageByUser(ctx context.Context, userID uint) (int64, error)
+	GetByKeys(ctx context.Context, keys []string) ([]entity.Media, error)
+	// SetBlogMedia, blogun medya bağlarını verilen listeyle değiştirir
+	SetBlogMedia(ctx context.Context, blogID uint, mediaIDs []uint) error
+	CountBlogRefs(ctx context.Context, mediaID uint) (int64, error)
+	// ListOrphans, hiçbir bloga bağlı olmayan ve before'dan önce yüklenmiş medya
+	ListOrphans(ctx context.Context, before time.Time, limit int) ([]entity.Media, error)
+}
+
+type mediaRepository struct{ db *gorm.DB }
+
+func NewMediaRepository(db *gorm.DB) MediaRepository {
+	return &mediaRepository{db: db}
+}
+
+func (r *mediaRepository) Create(ctx context.Context, m *entity.Media) error {
+	if err := r.db.WithContext(ctx).Create(m).Error; err != nil {
+		slog.ErrorContext(ctx, "media create error", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *mediaRepository) CreateWithinQuota(ctx context.Context, m *entity.Media, quota int64) (int64, error) {
+	var used int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user entity.User
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").First(&user, m.UserID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.Media{}).Where("user_id = ?", m.UserID).
+			Select("COALESCE(SUM(size), 0)").Scan(&used).Error; err != nil {
+			return err
+		}
+		if used+m.Size > quota {
+			return ErrQuotaExceeded
+		}
+		return tx.Create(m).Error
+	})
+	if err != nil && !errors.Is(err, ErrQuotaExceeded) {
+		slog.ErrorContext(ctx, "media createWithinQuota error", "err", err)
+	}
+	return used, err
+}
+
+func (r *mediaRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("media_id = ?", id).Delete(&entity.BlogMedia{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Media{}, id).Error
+	})
+}
+
+func (r *mediaRepository) GetByID(ctx context.Context, id uint) (*entity.Media, error) {
+	var m entity.Media
+	if err := r.db.WithContext(ctx).First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *mediaRepository) ListByUser(ctx context.Context, userID uint) ([]entity.Media, error) {
+	var list []entity.Media
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&list).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "media listByUser error", "err", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mediaRepository) UsageByUser(ctx context.Context, userID uint) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&entity.Media{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0)").Scan(&total).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "media usageByUser error", "err", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *mediaRepository) GetByKeys(ctx context.Context, keys []string) ([]entity.Media, error) {
+	var list []entity.Media
+	if len(keys) == 0 {
+		return list, nil
+	}
+	err := r.db.WithContext(ctx).Where("key IN ? OR thumb_key IN ?", keys, keys).Find(&list).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "media getByKeys error", "err", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mediaRepository) SetBlogMedia(ctx context.Context, blogID uint, mediaIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		del := tx.Where("blog_id = ?", blogID)
+		if len(mediaIDs) > 0 {
+			del = del.Where("media_id NOT IN ?", mediaIDs)
+		}
+		if err := del.Delete(&entity.BlogMedia{}).Error; err != nil {
+			return err
+		}
+		if len(mediaIDs) == 0 {
+			return nil
+		}
+		links := make([]entity.BlogMedia, len(mediaIDs))
+		for i, id := range mediaIDs {
+			links[i] = entity.BlogMedia{BlogID: blogID, MediaID: id}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+	})
+}
+
+func (r *mediaRepository) CountBlogRefs(ctx context.Context, mediaID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.BlogMedia{}).Where("media_id = ?", mediaID).Count(&count).Error
+	return count, err
+}
+
+func (r *mediaRepository) ListOrphans(ctx context.Context, before time.Time, limit int) ([]entity.Media, error) {
+	var list []entity.Media
+	err := r.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM blog_media bm WHERE bm.media_id = media.id)").
+		Order("id ASC").Limit(limit).
+		Find(&list).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "media listOrphans error", "err", err)
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, n *entity.Notification) error
+	ListByUser(ctx context.Context, username string, unreadOnly bool, limit int) ([]entity.Notification, error)
+	MarkRead(ctx context.Context, id uint, username string) error
+	MarkAllRead(ctx context.Context, username string) error
+}
+
+type notificationRepository struct{ db *gorm.DB }
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, n *entity.Notification) error {
+	err := r.db.WithContext(ctx).Create(n).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "notification create error", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *notificationRepository) ListByUser(ctx context.Context, username string, unreadOnly bool, limit int) ([]entity.Notification, error) {
+	var rows []entity.Notification
+	if limit <= 0 {
+		limit = 50
+	}
+	q := r.db.WithContext(ctx).Where("username = ?", username)
+	if unreadOnly {
+		q = q.Where("read_at IS NULL")
+	}
+	if err := q.Order("created_at DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, id uint, username string) error {
+	tx := r.db.WithContext(ctx).Model(&entity.Notification{}).
+		Where("id = ? AND username = ?", id, username).
+		Updates(map[string]interface{}{"read_at": time.Now(), "updated_at": time.Now()})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, username string) error {
+	return r.db.WithContext(ctx).Model(&entity.Notification{}).
+		Where("username = ? AND read_at IS NULL", username).
+		Updates(map[string]interface{}{"read_at": time.Now(), "updated_at": time.Now()}).Error
+}
@@ -0,0 +1,429 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrErasureNotPending, talep bu arada iptal edildiyse ya da işlendiyse döner
+var ErrErasureNotPending = errors.New("erasure request is not pending")
+
+type PrivacyRepository interface {
+	ListComments(ctx context.Context, userID uint) ([]entity.Comment, error)
+	ListReactions(ctx context.Context, userID uint) ([]UserReaction, error)
+	ListRoleRequests(ctx context.Context, username string) ([]entity.RoleRequest, error)
+
+	// PendingErasure, kullanıcının bekleyen talebi yoksa nil döner
+	PendingErasure(ctx context.Context, userID uint) (*entity.ErasureRequest, error)
+	CreateErasure(ctx context.Context, req *entity.ErasureRequest, audit *entity.AuditLog) error
+	CancelErasure(ctx context.Context, req *entity.ErasureRequest, audit *entity.AuditLog) error
+	ListDueErasures(ctx context.Context, now time.Time, limit int) ([]entity.ErasureRequest, error)
+	// Erase, kullanıcıyı anonimleştirir ve audit kaydını aynı transaction'da yazar
+	Erase(ctx context.Context, req *entity.ErasureRequest, policy, placeholder string) (*ErasureStats, error)
+}
+
+// UserReaction, kişisel veri export'u için blog başlığıyla birlikte tepki
+type UserReaction struct {
+	BlogID    uint
+	BlogTitle string
+	Type      string
+	CreatedAt time.Time
+}
+
+// ErasureStats, anonimleştirmede etkilenen kayıt sayıları (audit detayına yazılır)
+type ErasureStats struct {
+	Policy             string `json:"policy"`
+	BlogsReassigned    int64  `json:"blogs_reassigned"`
+	BlogsDeleted       int64  `json:"blogs_deleted"`
+	CommentsReassigned int64  `json:"comments_reassigned"`
+	CommentsDeleted    int64  `json:"comments_deleted"`
+	ReactionsDeleted   int64  `json:"reactions_deleted"`
+	BookmarksDeleted   int64  `json:"bookmarks_deleted"`
+	Notifications      int64  `json:"notifications_deleted"`
+	RoleRequests       int64  `json:"role_requests_deleted"`
+	// başka kayıtlarda geçen kullanıcı adı ve e-posta
+	NotificationsScrubbed     int64 `json:"notifications_scrubbed"`
+	WebhookDeliveriesScrubbed int64 `json:"webhook_deliveries_scrubbed"`
+	ImportMappingsDeleted     int64 `json:"import_mappings_deleted"`
+}
+
+type privacyRepository struct{ db *gorm.DB }
+
+func NewPrivacyRepository(db *gorm.DB) PrivacyRepository {
+	return &privacyRepository{db: db}
+}
+
+func (r *privacyRepository) ListComments(ctx context.Context, userID uint) ([]entity.Comment, error) {
+	var comments []entity.Comment
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&comments).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "privacy listComments error", "err", err)
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *privacyRepository) ListReactions(ctx context.Context, userID uint) ([]UserReaction, error) {
+	var rows []UserReaction
+	err := r.db.WithContext(ctx).Table("reactions").
+		Select("reactions.blog_id, blogs.title AS blog_title, reactions.type, reactions.created_at").
+		Joins("LEFT JOIN blogs ON blogs.id = reactions.blog_id").
+		Where("reactions.user_id = ?", userID).
+		Order("reactions.created_at ASC").
+		Scan(&rows).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "privacy listReactions error", "err", err)
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *privacyRepository) ListRoleRequests(ctx context.Context, username string) ([]entity.RoleRequest, error) {
+	var list []entity.RoleRequest
+	err := r.db.WithContext(ctx).
+		Where("username = ?", username).
+		Order("created_at ASC").
+		Find(&list).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "privacy listRoleRequests error", "err", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *privacyRepository) PendingErasure(ctx context.Context, userID uint) (*entity.ErasureRequest, error) {
+	var req entity.ErasureRequest
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, entity.ErasurePending).
+		First(&req).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r *privacyRepository) CreateErasure(ctx context.Context, req *entity.ErasureRequest, audit *entity.AuditLog) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(req).Error; err != nil {
+			return err
+		}
+		audit.TargetID = req.UserID
+		return tx.Create(audit).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "privacy createErasure error", "err", err)
+	}
+	return err
+}
+
+func (r *privacyRepository) CancelErasure(ctx context.Context, req *entity.ErasureRequest, audit *entity.AuditLog) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entity.ErasureRequest{}).
+			Where("id = ? AND status = ?", req.ID, entity.ErasurePending).
+			Updates(map[string]interface{}{
+				"status":     entity.ErasureCancelled,
+				"updated_at": time.Now(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrErasureNotPending
+		}
+		return tx.Create(audit).Error
+	})
+	if err != nil && !errors.Is(err, ErrErasureNotPending) {
+		slog.ErrorContext(ctx, "privacy cancelErasure error", "err", err)
+	}
+	return err
+}
+
+func (r *privacyRepository) ListDueErasures(ctx context.Context, now time.Time, limit int) ([]entity.ErasureRequest, error) {
+	var list []entity.ErasureRequest
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND scheduled_at <= ?", entity.ErasurePending, now).
+		Order("scheduled_at ASC").Limit(limit).
+		Find(&list).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "privacy listDueErasures error", "err", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *privacyRepository) Erase(ctx context.Context, req *entity.ErasureRequest, policy, placeholder string) (*ErasureStats, error) {
+	stats := &ErasureStats{Policy: policy}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// talep bu arada iptal edildiyse ya da başka bir instance işlediyse dokunma
+		res := tx.Model(&entity.ErasureRequest{}).
+			Where("id = ? AND status = ?", req.ID, entity.ErasurePending).
+			Updates(map[string]interface{}{
+				"status":       entity.ErasureCompleted,
+				"completed_at": time.Now(),
+				"updated_at":   time.Now(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrErasureNotPending
+		}
+
+		var user entity.User
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, req.UserID).Error; err != nil {
+			return err
+		}
+		if user.ErasedAt != nil {
+			return nil
+		}
+		oldName := user.Username
+		anonName := fmt.Sprintf("erased-%d", user.ID)
+		anonEmail := anonName + "@invalid.local"
+
+		var err error
+		switch policy {
+		case entity.ErasurePolicyDelete:
+			err = r.deleteContent(tx, &user, anonName, stats)
+		default:
+			err = r.reassignContent(tx, &user, placeholder, stats)
+		}
+		if err != nil {
+			return err
+		}
+
+		// tepkiler: sayaçlar da aynı transaction'da düşürülür
+		if err := tx.Exec(`UPDATE blog_reaction_counts c SET count = GREATEST(c.count - r.n, 0)
+			FROM (SELECT blog_id, type, COUNT(*) AS n FROM reactions WHERE user_id = ? GROUP BY blog_id, type) r
+			WHERE c.blog_id = r.blog_id AND c.type = r.type`, user.ID).Error; err != nil {
+			return err
+		}
+		res = tx.Where("user_id = ?", user.ID).Delete(&entity.Reaction{})
+		if res.Error != nil {
+			return res.Error
+		}
+		stats.ReactionsDeleted = res.RowsAffected
+
+		res = tx.Where("user_id = ?", user.ID).Delete(&entity.Bookmark{})
+		if res.Error != nil {
+			return res.Error
+		}
+		stats.BookmarksDeleted = res.RowsAffected
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&entity.ReadingList{}).Error; err != nil {
+			return err
+		}
+
+		res = tx.Unscoped().Where("username = ?", oldName).Delete(&entity.Notification{})
+		if res.Error != nil {
+			return res.Error
+		}
+		stats.Notifications = res.RowsAffected
+
+		res = tx.Where("username = ?", oldName).Delete(&entity.RoleRequest{})
+		if res.Error != nil {
+			return res.Error
+		}
+		stats.RoleRequests = res.RowsAffected
+
+		if err := tx.Where("viewer_key = ?", fmt.Sprintf("u:%d", user.ID)).Delete(&entity.BlogView{}).Error; err != nil {
+			return err
+		}
+
+		if err := r.scrubReferences(tx, &user, anonName, anonEmail, stats); err != nil {
+			return err
+		}
+
+		// kullanıcı adının geçtiği diğer kayıtlar anonim ada çevrilir
+		renames := []struct {
+			model  interface{}
+			column string
+		}{
+			{&entity.RoleRequest{}, "decided_by"},
+			{&entity.Webhook{}, "created_by"},
+			{&entity.AuditLog{}, "actor"},
+		}
+		for _, rn := range renames {
+			if err := tx.Unscoped().Model(rn.model).Where(rn.column+" = ?", oldName).
+				Update(rn.column, anonName).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		if err := tx.Unscoped().Model(&entity.User{}).Where("id = ?", user.ID).
+			Updates(map[string]interface{}{
+				"username":            anonName,
+				"email":               anonEmail,
+				"password":            "", // bcrypt hash olmadığı için giriş yapılamaz
+				"role":                entity.RoleReader,
+				"must_reset_password": false,
+				"erased_at":           now,
+				"deleted_at":          gorm.Expr("COALESCE(deleted_at, ?)", now),
+				"updated_at":          now,
+			}).Error; err != nil {
+			return err
+		}
+
+		details, err := json.Marshal(stats)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&entity.AuditLog{
+			Actor:      "system",
+			Action:     entity.AuditErasureCompleted,
+			TargetType: "user",
+			TargetID:   user.ID,
+			Details:    string(details),
+			CreatedAt:  now,
+		}).Error
+	})
+	if err != nil {
+		if !errors.Is(err, ErrErasureNotPending) {
+			slog.ErrorContext(ctx, "privacy erase error", "err", err)
+		}
+		return nil, err
+	}
+	return stats, nil
+}
+
+// scrubReferences, kullanıcı adı ve e-postanın kopyalandığı kayıtları temizler: diğer kullanıcılara
+// giden bildirimler (admin event'leri kalıcı değil, sadece bus'tan geçer), webhook teslimat payload'ları
+// (örn. user.registered) ve WXR import'unda e-postadan üretilen eşleştirmeler
+func (r *privacyRepository) scrubReferences(tx *gorm.DB, user *entity.User, anonName, anonEmail string, stats *ErasureStats) error {
+	// kullanıcı adı mesajın içinde geçer; "ali" "alican"ın parçası olarak değiştirilmesin diye
+	// önünde ve arkasında kullanıcı adı karakteri olmayan eşleşmeler alınır
+	pattern := `(^|[^A-Za-z0-9_.-])` + regexp.QuoteMeta(user.Username) + `($|[^A-Za-z0-9_.-])`
+	res := tx.Exec(`UPDATE notifications SET message = regexp_replace(message, ?, ?, 'g'),
+			link = regexp_replace(link, ?, ?, 'g')
+		WHERE message ~ ? OR link ~ ?`,
+		pattern, `\1`+anonName+`\2`, pattern, `\1`+anonName+`\2`, pattern, pattern)
+	if res.Error != nil {
+		return res.Error
+	}
+	stats.NotificationsScrubbed = res.RowsAffected
+
+	// payload JSON olduğu için değerler tırnaklı haliyle aranır, başka string'lerin parçası değişmez
+	jsonString := func(s string) string {
+		b, _ := json.Marshal(s)
+		return string(b)
+	}
+	name, email := jsonString(user.Username), jsonString(user.Email)
+	if user.Email == "" {
+		email = name // `""` her payload'da geçer
+	}
+	res = tx.Exec(`UPDATE webhook_deliveries SET payload = replace(replace(payload, ?, ?), ?, ?)
+		WHERE strpos(payload, ?) > 0 OR strpos(payload, ?) > 0`,
+		name, jsonString(anonName), email, jsonString(anonEmail), name, email)
+	if res.Error != nil {
+		return res.Error
+	}
+	stats.WebhookDeliveriesScrubbed = res.RowsAffected
+
+	// e-posta ile eşleşen yorum yazarları "email:<adres>" anahtarıyla tutulur
+	res = tx.Where("kind = ? AND (source_id = ? OR (target_id = ? AND source_id LIKE 'email:%'))",
+		entity.ImportKindUser, "email:"+strings.ToLower(strings.TrimSpace(user.Email)), user.ID).
+		Delete(&entity.ImportMapping{})
+	if res.Error != nil {
+		return res.Error
+	}
+	stats.ImportMappingsDeleted = res.RowsAffected
+	return nil
+}
+
+// reassignContent, yazıları, yorumları ve yazılarda kullanılan medyayı placeholder kullanıcıya aktarır
+func (r *privacyRepository) reassignContent(tx *gorm.DB, user *entity.User, placeholder string, stats *ErasureStats) error {
+	var ph entity.User
+	err := tx.Unscoped().Where("username = ?", placeholder).First(&ph).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ph = entity.User{
+			Username:  placeholder,
+			Email:     placeholder + "@invalid.local",
+			Role:      entity.RoleReader,
+			Followers: []string{},
+		}
+		ph.CreatedAt, ph.UpdatedAt = time.Now(), time.Now()
+		err = tx.Create(&ph).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	res := tx.Unscoped().Model(&entity.Blog{}).
+		Where("author_id = ? OR username = ?", user.ID, user.Username).
+		Updates(map[string]interface{}{"author_id": ph.ID, "username": ph.Username})
+	if res.Error != nil {
+		return res.Error
+	}
+	stats.BlogsReassigned = res.RowsAffected
+
+	res = tx.Unscoped().Model(&entity.Comment{}).Where("user_id = ?", user.ID).Update("user_id", ph.ID)
+	if res.Error != nil {
+		return res.Error
+	}
+	stats.CommentsReassigned = res.RowsAffected
+
+	return tx.Model(&entity.Media{}).Where("user_id = ?", user.ID).
+		Updates(map[string]interface{}{"user_id": ph.ID, "username": ph.Username}).Error
+}
+
+// deleteContent, yazıları bağlı kayıtlarıyla birlikte ve kullanıcının yorumlarını kalıcı olarak siler.
+// Referanssız kalan medya dosyaları media GC tarafından temizlenir.
+func (r *privacyRepository) deleteContent(tx *gorm.DB, user *entity.User, anonName string, stats *ErasureStats) error {
+	var blogIDs []uint
+	if err := tx.Unscoped().Model(&entity.Blog{}).
+		Where("author_id = ? OR username = ?", user.ID, user.Username).
+		Pluck("id", &blogIDs).Error; err != nil {
+		return err
+	}
+
+	if len(blogIDs) > 0 {
+		res := tx.Unscoped().Where("blog_id IN ?", blogIDs).Delete(&entity.Comment{})
+		if res.Error != nil {
+			return res.Error
+		}
+		stats.CommentsDeleted += res.RowsAffected
+
+		// bookmark'lar başkalarının listelerinde "deleted" olarak kalır; saklanan başlık silinen içeriğin
+		// parçası olduğu için temizlenir
+		if err := tx.Model(&entity.Bookmark{}).Where("blog_id IN ?", blogIDs).Update("blog_title", "").Error; err != nil {
+			return err
+		}
+		for _, model := range []interface{}{
+			&entity.Reaction{}, &entity.BlogReactionCount{}, &entity.BlogMedia{},
+			&entity.BlogView{}, &entity.BlogDailyStat{}, &entity.TrendingScore{},
+		} {
+			if err := tx.Where("blog_id IN ?", blogIDs).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		res = tx.Unscoped().Where("id IN ?", blogIDs).Delete(&entity.Blog{})
+		if res.Error != nil {
+			return res.Error
+		}
+		stats.BlogsDeleted = res.RowsAffected
+	}
+
+	res := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&entity.Comment{})
+	if res.Error != nil {
+		return res.Error
+	}
+	stats.CommentsDeleted += res.RowsAffected
+
+	return tx.Model(&entity.Media{}).Where("user_id = ?", user.ID).Update("username", anonName).Error
+}
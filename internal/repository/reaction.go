@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReactionRepository interface {
+	Add(ctx context.Context, blogID, userID uint, reactionType string) (bool, error)
+	Remove(ctx context.Context, blogID, userID uint, reactionType string) (bool, error)
+	TypesByUser(ctx context.Context, blogID, userID uint) ([]string, error)
+	ListBlogsByUserReaction(ctx context.Context, userID uint, reactionType string, limit int) ([]entity.Blog, error)
+}
+
+type reactionRepository struct{ db *gorm.DB }
+
+func NewReactionRepository(db *gorm.DB) ReactionRepository {
+	return &reactionRepository{db: db}
+}
+
+// Add, tepki zaten varsa false döner. Sayaç sadece gerçekten eklenen tepki için artar.
+func (r *reactionRepository) Add(ctx context.Context, blogID, userID uint, reactionType string) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.Reaction{
+			BlogID:    blogID,
+			UserID:    userID,
+			Type:      reactionType,
+			CreatedAt: time.Now(),
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		created = true
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "blog_id"}, {Name: "type"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("blog_reaction_counts.count + 1")}),
+		}).Create(&entity.BlogReactionCount{BlogID: blogID, Type: reactionType, Count: 1}).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "reaction add error", "err", err)
+		return false, err
+	}
+	return created, nil
+}
+
+func (r *reactionRepository) Remove(ctx context.Context, blogID, userID uint, reactionType string) (bool, error) {
+	removed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("blog_id = ? AND user_id = ? AND type = ?", blogID, userID, reactionType).
+			Delete(&entity.Reaction{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		removed = true
+
+		return tx.Model(&entity.BlogReactionCount{}).
+			Where("blog_id = ? AND type = ? AND count > 0", blogID, reactionType).
+			Update("count", gorm.Expr("count - 1")).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "reaction remove error", "err", err)
+		return false, err
+	}
+	return removed, nil
+}
+
+func (r *reactionRepository) TypesByUser(ctx context.Context, blogID, userID uint) ([]string, error) {
+	var types []string
+	err := r.db.WithContext(ctx).Model(&entity.Reaction{}).
+		Where("blog_id = ? AND user_id = ?", blogID, userID).
+		Order("type").
+		Pluck("type", &types).Error
+	if err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+// ListBlogsByUserReaction, kullanıcının tepki verdiği onaylı blogları son tepkiden başlayarak getirir
+func (r *reactionRepository) ListBlogsByUserReaction(ctx context.Context, userID uint, reactionType string, limit int) ([]entity.Blog, error) {
+	var blogs []entity.Blog
+	if limit <= 0 {
+		limit = 50
+	}
+	err := r.db.WithContext(ctx).Preload("ReactionCounts").
+		Joins("JOIN reactions ON reactions.blog_id = blogs.id").
+		Where("reactions.user_id = ? AND reactions.type = ?", userID, reactionType).
+		Where("blogs.is_approved = ?", true).
+		Order("reactions.created_at DESC").
+		Limit(limit).
+		Find(&blogs).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "reaction listBlogsByUserReaction error", "err", err)
+		return nil, err
+	}
+	return blogs, nil
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RetentionRepository interface {
+	// Plan, saklama süresi dolan kayıtları ve onlara bağlı olduğu için silinecek kayıtları bulur
+	Plan(ctx context.Context, cutoffs RetentionCutoffs, limit int) (*PurgePlan, error)
+	// Purge, planı ve audit kaydını tek transaction'da uygular
+	Purge(ctx context.Context, plan *PurgePlan, audit *entity.AuditLog) error
+}
+
+// RetentionCutoffs, bu tarihten önce soft-delete edilen kayıtlar silinir; sıfır değer o türü atlar
+type RetentionCutoffs struct {
+	Users         time.Time
+	Blogs         time.Time
+	Comments      time.Time
+	Notifications time.Time
+}
+
+// PurgePlan, kalıcı olarak silinecek kayıtlar. Silinen kullanıcıların blogları, yorumları ve
+// bildirimleri ile silinen blogların yorumları da listelere dahildir.
+type PurgePlan struct {
+	UserIDs         []uint
+	Usernames       []string
+	BlogIDs         []uint
+	CommentIDs      []uint
+	NotificationIDs []uint
+
+	// sadece raporlama için, bunlar ID listesi olmadan silinir
+	Reactions  int64
+	MediaLinks int64 // blog_media; referanssız kalan medya dosyaları media GC ile temizlenir
+	Bookmarks  int64
+}
+
+func (p *PurgePlan) Empty() bool {
+	return len(p.UserIDs) == 0 && len(p.BlogIDs) == 0 && len(p.CommentIDs) == 0 && len(p.NotificationIDs) == 0
+}
+
+type retentionRepository struct{ db *gorm.DB }
+
+func NewRetentionRepository(db *gorm.DB) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+func (r *retentionRepository) Plan(ctx context.Context, cutoffs RetentionCutoffs, limit int) (*PurgePlan, error) {
+	plan := &PurgePlan{}
+	// Session: aynı instance üzerinde koşulların birikmemesi için
+	db := r.db.WithContext(ctx).Unscoped().Session(&gorm.Session{})
+
+	if !cutoffs.Users.IsZero() {
+		var users []entity.User
+		if err := db.Select("id", "username").
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoffs.Users).
+			Order("id").Limit(limit).
+			Find(&users).Error; err != nil {
+			slog.ErrorContext(ctx, "retention plan users error", "err", err)
+			return nil, err
+		}
+		for _, u := range users {
+			plan.UserIDs = append(plan.UserIDs, u.ID)
+			plan.Usernames = append(plan.Usernames, u.Username)
+		}
+	}
+
+	var err error
+	if !cutoffs.Blogs.IsZero() {
+		if plan.BlogIDs, err = expiredIDs(db, &entity.Blog{}, cutoffs.Blogs, limit); err != nil {
+			return nil, err
+		}
+	}
+	if len(plan.UserIDs) > 0 {
+		var ids []uint
+		if err := db.Model(&entity.Blog{}).
+			Where("author_id IN ? OR username IN ?", plan.UserIDs, plan.Usernames).
+			Pluck("id", &ids).Error; err != nil {
+			return nil, err
+		}
+		plan.BlogIDs = mergeIDs(plan.BlogIDs, ids)
+	}
+
+	if !cutoffs.Comments.IsZero() {
+		if plan.CommentIDs, err = expiredIDs(db, &entity.Comment{}, cutoffs.Comments, limit); err != nil {
+			return nil, err
+		}
+	}
+	if len(plan.BlogIDs) > 0 || len(plan.UserIDs) > 0 {
+		var ids []uint
+		if err := db.Model(&entity.Comment{}).
+			Where("blog_id IN ? OR user_id IN ?", nonEmpty(plan.BlogIDs), nonEmpty(plan.UserIDs)).
+			Pluck("id", &ids).Error; err != nil {
+			return nil, err
+		}
+		plan.CommentIDs = mergeIDs(plan.CommentIDs, ids)
+	}
+
+	if !cutoffs.Notifications.IsZero() {
+		if plan.NotificationIDs, err = expiredIDs(db, &entity.Notification{}, cutoffs.Notifications, limit); err != nil {
+			return nil, err
+		}
+	}
+	if len(plan.Usernames) > 0 {
+		var ids []uint
+		if err := db.Model(&entity.Notification{}).
+			Where("username IN ?", plan.Usernames).
+			Pluck("id", &ids).Error; err != nil {
+			return nil, err
+		}
+		plan.NotificationIDs = mergeIDs(plan.NotificationIDs, ids)
+	}
+
+	if len(plan.BlogIDs) > 0 || len(plan.UserIDs) > 0 {
+		if err := db.Model(&entity.Reaction{}).
+			Where("blog_id IN ? OR user_id IN ?", nonEmpty(plan.BlogIDs), nonEmpty(plan.UserIDs)).
+			Count(&plan.Reactions).Error; err != nil {
+			return nil, err
+		}
+	}
+	if len(plan.BlogIDs) > 0 {
+		if err := db.Model(&entity.BlogMedia{}).
+			Where("blog_id IN ?", plan.BlogIDs).
+			Count(&plan.MediaLinks).Error; err != nil {
+			return nil, err
+		}
+	}
+	if len(plan.UserIDs) > 0 {
+		if err := db.Model(&entity.Bookmark{}).
+			Where("user_id IN ?", plan.UserIDs).
+			Count(&plan.Bookmarks).Error; err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+func (r *retentionRepository) Purge(ctx context.Context, plan *PurgePlan, audit *entity.AuditLog) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx = tx.Unscoped().Session(&gorm.Session{})
+
+		if len(plan.UserIDs) > 0 {
+			// silinen kullanıcıların kalan bloglardaki tepkileri sayaçlardan düşülür
+			if err := tx.Exec(`UPDATE blog_reaction_counts c SET count = GREATEST(c.count - r.n, 0)
+				FROM (SELECT blog_id, type, COUNT(*) AS n FROM reactions WHERE user_id IN ? GROUP BY blog_id, type) r
+				WHERE c.blog_id = r.blog_id AND c.type = r.type`, plan.UserIDs).Error; err != nil {
+				return err
+			}
+			for _, model := range []interface{}{&entity.Reaction{}, &entity.Bookmark{}, &entity.ReadingList{}, &entity.ErasureRequest{}} {
+				if err := tx.Where("user_id IN ?", plan.UserIDs).Delete(model).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Where("username IN ?", plan.Usernames).Delete(&entity.RoleRequest{}).Error; err != nil {
+				return err
+			}
+			viewerKeys := make([]string, len(plan.UserIDs))
+			for i, id := range plan.UserIDs {
+				viewerKeys[i] = fmt.Sprintf("u:%d", id)
+			}
+			if err := tx.Where("viewer_key IN ?", viewerKeys).Delete(&entity.BlogView{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(plan.CommentIDs) > 0 {
+			if err := tx.Where("id IN ?", plan.CommentIDs).Delete(&entity.Comment{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(plan.BlogIDs) > 0 {
+			// bookmark'lar BlogTitle ile saklandığı için başkalarının listelerinde kalır
+			for _, model := range []interface{}{
+				&entity.Reaction{}, &entity.BlogReactionCount{}, &entity.BlogMedia{},
+				&entity.BlogView{}, &entity.BlogDailyStat{}, &entity.TrendingScore{},
+			} {
+				if err := tx.Where("blog_id IN ?", plan.BlogIDs).Delete(model).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Where("id IN ?", plan.BlogIDs).Delete(&entity.Blog{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(plan.NotificationIDs) > 0 {
+			if err := tx.Where("id IN ?", plan.NotificationIDs).Delete(&entity.Notification{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(plan.UserIDs) > 0 {
+			if err := tx.Where("id IN ?", plan.UserIDs).Delete(&entity.User{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// silinen kayıtların import eşleşmeleri de kaldırılır, aksi halde tekrar import'ta atlanırlar
+		for kind, ids := range map[string][]uint{
+			entity.ImportKindUser:    plan.UserIDs,
+			entity.ImportKindPost:    plan.BlogIDs,
+			entity.ImportKindComment: plan.CommentIDs,
+		} {
+			if len(ids) == 0 {
+				continue
+			}
+			if err := tx.Where("kind = ? AND target_id IN ?", kind, ids).Delete(&entity.ImportMapping{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(audit).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "retention purge error", "err", err)
+	}
+	return err
+}
+
+// expiredIDs, cutoff'tan önce soft-delete edilmiş kayıtların id'leri
+func expiredIDs(db *gorm.DB, model interface{}, cutoff time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := db.Model(model).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Order("id").Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		slog.ErrorContext(db.Statement.Context, "retention expiredIDs error", "err", err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+func mergeIDs(a, b []uint) []uint {
+	seen := make(map[uint]bool, len(a))
+	for _, id := range a {
+		seen[id] = true
+	}
+	for _, id := range b {
+		if !seen[id] {
+			seen[id] = true
+			a = append(a, id)
+		}
+	}
+	return a
+}
+
+// nonEmpty, boş listeyi IN () hatası vermeyecek, hiçbir id ile eşleşmeyen listeye çevirir
+func nonEmpty(ids []uint) []uint {
+	if len(ids) == 0 {
+		return []uint{0}
+	}
+	return ids
+}
@@ -3,7 +3,7 @@ package repository
 import (
 	"cleanArch_with_postgres/internal/entity"
 	"context"
-	"fmt"
+	"log/slog"
 	"time"
 
 	"gorm.io/gorm"
@@ -58,14 +58,14 @@ func (r *roleRequestRepository) Approve(ctx context.Context, id uint, admin stri
 		Where("id = ? AND status = ?", id, entity.RoleReqPending).
 		Updates(map[string]interface{}{"status": entity.RoleReqApproved, "decided_by": admin, "decided_at": &now}).Error
 	if err != nil {
-		fmt.Println("Error approving role request:", err)
+		slog.ErrorContext(ctx, "role request approve error", "err", err)
 		return err
 	} else {
 		err = r.db.WithContext(ctx).Model(&entity.User{}).
 			Where("username = (SELECT username FROM role_requests WHERE id = ?)", id).
 			Update("role", "admin").Error
 		if err != nil {
-			fmt.Println("Error updating user role to admin:", err)
+			slog.ErrorContext(ctx, "role request promote user error", "err", err)
 			return err
 		}
 		return nil
@@ -77,7 +77,7 @@ func (r *roleRequestRepository) Reject(ctx context.Context, id uint, admin strin
 		Where("id = ? AND status = ?", id, entity.RoleReqPending).
 		Updates(map[string]interface{}{"status": entity.RoleReqRejected, "decided_by": admin, "decided_at": &now}).Error
 	if err != nil {
-		fmt.Println("Error rejecting role request:", err)
+		slog.ErrorContext(ctx, "role request reject error", "err", err)
 		return err
 	}
 	return nil
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// trendingLockKey, pg_try_advisory_xact_lock anahtarı; aynı anda tek instance hesaplasın diye
+const trendingLockKey = 310031
+
+const trendingCheckpoint = "trending"
+
+type TrendingWindow struct {
+	Name     string
+	Length   time.Duration // bu süreden eski aktivite pencereye girmez
+	HalfLife time.Duration
+}
+
+type TrendingWeights struct {
+	View     float64
+	Reaction float64
+	Comment  float64
+}
+
+type TrendingBlog struct {
+	entity.Blog
+	Score float64
+}
+
+type TrendingRepository interface {
+	Refresh(ctx context.Context, now time.Time, windows []TrendingWindow, w TrendingWeights) (bool, error)
+	Top(ctx context.Context, period string, limit int) ([]TrendingBlog, error)
+}
+
+type trendingRepository struct{ db *gorm.DB }
+
+func NewTrendingRepository(db *gorm.DB) TrendingRepository {
+	return &trendingRepository{db: db}
+}
+
+type blogScore struct {
+	BlogID uint
+	Score  float64
+	LastAt time.Time
+}
+
+// activitySQL: son checkpoint'ten bu yana gelen okuma, tepki ve yorumları now'a göre sönümleyerek toplar
+const activitySQL = `
+SELECT blog_id, SUM(w * EXP(-EXTRACT(EPOCH FROM (CAST(@now AS timestamptz) - ts)) / @tau)) AS score, MAX(ts) AS last_at
+FROM (
+	SELECT blog_id, viewed_at AS ts, CAST(@view_w AS float8) AS w FROM blog_views WHERE viewed_at > @since AND viewed_at <= @now
+	UNION ALL
+	SELECT blog_id, created_at, CAST(@reaction_w AS float8) FROM reactions WHERE created_at > @since AND created_at <= @now
+	UNION ALL
+	SELECT blog_id, created_at, CAST(@comment_w AS float8) FROM comments WHERE deleted_at IS NULL AND created_at > @since AND created_at <= @now
+) activity
+GROUP BY blog_id`
+
+// Refresh, skorları artımlı günceller: önce mevcut skorlar geçen süre kadar sönümlenir,
+// sonra checkpoint'ten sonraki yeni aktivite eklenir, pencere dışına düşen bloglar silinir.
+// Başka bir instance o an hesaplıyorsa false döner.
+func (r *trendingRepository) Refresh(ctx context.Context, now time.Time, windows []TrendingWindow, w TrendingWeights) (bool, error) {
+	ran := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", trendingLockKey).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+		ran = true
+
+		var cp entity.JobCheckpoint
+		err := tx.Where("name = ?", trendingCheckpoint).First(&cp).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+		last := cp.At
+
+		for _, win := range windows {
+			tau := win.HalfLife.Seconds() / math.Ln2
+			since := now.Add(-win.Length)
+			if !last.IsZero() && last.After(since) {
+				since = last
+				elapsed := now.Sub(last).Seconds()
+				if err := tx.Model(&entity.TrendingScore{}).
+					Where("period = ?", win.Name).
+					Updates(map[string]interface{}{
+						"score":      gorm.Expr("score * EXP(?::float8 / ?::float8)", -elapsed, tau),
+						"updated_at": now,
+					}).Error; err != nil {
+					return err
+				}
+			} else {
+				// ilk çalıştırma veya uzun aradan sonra: tüm pencere baştan hesaplanır
+				if err := tx.Where("period = ?", win.Name).Delete(&entity.TrendingScore{}).Error; err != nil {
+					return err
+				}
+			}
+
+			var scores []blogScore
+			if err := tx.Raw(activitySQL, map[string]interface{}{
+				"now":        now,
+				"since":      since,
+				"tau":        tau,
+				"view_w":     w.View,
+				"reaction_w": w.Reaction,
+				"comment_w":  w.Comment,
+			}).Scan(&scores).Error; err != nil {
+				return err
+			}
+
+			if len(scores) > 0 {
+				rows := make([]entity.TrendingScore, len(scores))
+				for i, s := range scores {
+					rows[i] = entity.TrendingScore{BlogID: s.BlogID, Period: win.Name, Score: s.Score, LastActivityAt: s.LastAt, UpdatedAt: now}
+				}
+				if err := tx.Clauses(clause.OnConflict{
+					Columns: []clause.Column{{Name: "blog_id"}, {Name: "period"}},
+					DoUpdates: clause.Assignments(map[string]interface{}{
+						"score":            gorm.Expr("trending_scores.score + excluded.score"),
+						"last_activity_at": gorm.Expr("GREATEST(trending_scores.last_activity_at, excluded.last_activity_at)"),
+						"updated_at":       now,
+					}),
+				}).CreateInBatches(rows, 500).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Where("period = ? AND last_activity_at < ?", win.Name, now.Add(-win.Length)).
+				Delete(&entity.TrendingScore{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(&entity.JobCheckpoint{Name: trendingCheckpoint, At: now}).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "trending refresh error", "err", err)
+		return false, err
+	}
+	return ran, nil
+}
+
+// Top, sadece onaylı ve silinmemiş blogları skora göre sıralar
+func (r *trendingRepository) Top(ctx context.Context, period string, limit int) ([]TrendingBlog, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	var scores []entity.TrendingScore
+	err := r.db.WithContext(ctx).
+		Joins("JOIN blogs ON blogs.id = trending_scores.blog_id AND blogs.deleted_at IS NULL AND blogs.is_approved = ?", true).
+		Where("trending_scores.period = ?", period).
+		Order("trending_scores.score DESC").
+		Limit(limit).
+		Find(&scores).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "trending top error", "err", err)
+		return nil, err
+	}
+	if len(scores) == 0 {
+		return []TrendingBlog{}, nil
+	}
+
+	ids := make([]uint, len(scores))
+	for i, s := range scores {
+		ids[i] = s.BlogID
+	}
+	var blogs []entity.Blog
+	if err := r.db.WithContext(ctx).Preload("ReactionCounts").Where("id IN ?", ids).Find(&blogs).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]entity.Blog, len(blogs))
+	for _, b := range blogs {
+		byID[b.ID] = b
+	}
+
+	out := make([]TrendingBlog, 0, len(scores))
+	for _, s := range scores {
+		if b, ok := byID[s.BlogID]; ok {
+			out = append(out, TrendingBlog{Blog: b, Score: s.Score})
+		}
+	}
+	return out, nil
+}
@@ -3,7 +3,7 @@ package repository
 import (
 	"cleanArch_with_postgres/internal/entity"
 	"context"
-	"fmt"
+	"log/slog"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,6 +15,7 @@ type UserRepository interface {
 	Delete(ctx context.Context, username string) error
 	ExistUser(ctx context.Context, email, username string) (bool, error)
 	GetByUsername(ctx context.Context, username string) (*entity.User, error)
+	GetByID(ctx context.Context, id uint) (*entity.User, error)
 	GetByIdentifier(ctx context.Context, identifier string) (*entity.User, error)
 	SearchByUsernamePrefix(ctx context.Context, prefix string, limit int) ([]entity.User, error)
 	SearchByUsernamePrefixWithOptions(ctx context.Context, prefix string, limit int, includeDeleted bool) ([]entity.User, error)
@@ -33,7 +34,7 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 	err := r.db.WithContext(ctx).Create(user).Error
 	if err != nil {
-		fmt.Println("user create error:", err)
+		slog.ErrorContext(ctx, "user create error", "err", err)
 		return err
 	}
 	return nil
@@ -46,11 +47,15 @@ func (r *userRepository) Update(ctx context.Context, oldUsername string, user *e
 			"username":   user.Username,
 			"email":      user.Email,
 			"password":   user.Password,
+			"role":       user.Role,
+			"locale":     user.Locale,
 			"updated_at": time.Now(),
+
+			"must_reset_password": user.MustResetPassword,
 		}).Error
 
 	if err != nil {
-		fmt.Println("user update error:", err)
+		slog.ErrorContext(ctx, "user update error", "err", err)
 		return err
 	}
 	return nil
@@ -59,7 +64,7 @@ func (r *userRepository) Update(ctx context.Context, oldUsername string, user *e
 func (r *userRepository) Delete(ctx context.Context, username string) error {
 	user, err := r.GetByUsername(ctx, username)
 	if err != nil {
-		fmt.Println("user delete error:", err)
+		slog.ErrorContext(ctx, "user delete error", "err", err)
 		return err
 	}
 	err = r.db.WithContext(ctx).Model(user).
@@ -69,7 +74,7 @@ func (r *userRepository) Delete(ctx context.Context, username string) error {
 		}).Error
 
 	if err != nil {
-		fmt.Println("user delete error2:", err)
+		slog.ErrorContext(ctx, "user delete error", "err", err)
 		return err
 	}
 	return nil
@@ -112,6 +117,14 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*e
 	return &user, nil
 }
 
+func (r *userRepository) GetByID(ctx context.Context, id uint) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *userRepository) GetByIdentifier(ctx context.Context, identifier string) (*entity.User, error) {
 	var user entity.User
 
@@ -164,7 +177,8 @@ func (r *userRepository) Restore(ctx context.Context, username string) error {
 	tx := r.db.WithContext(ctx).
 		Model(&entity.User{}).
 		Unscoped(). // deleted_at NULL yapabilmek için
-		Where("username = ?", username).
+		// anonimleştirilen (erased) hesaplar geri yüklenemez
+		Where("username = ? AND erased_at IS NULL", username).
 		Updates(map[string]interface{}{
 			"deleted_at": nil,
 			"updated_at": time.Now(),
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, w *entity.Webhook) error
+	Update(ctx context.Context, w *entity.Webhook) error
+	Delete(ctx context.Context, id uint) error
+	GetByID(ctx context.Context, id uint) (*entity.Webhook, error)
+	List(ctx context.Context) ([]entity.Webhook, error)
+	ListActiveForEvent(ctx context.Context, eventType string) ([]entity.Webhook, error)
+
+	CreateDeliveries(ctx context.Context, ds []entity.WebhookDelivery) error
+	SaveDelivery(ctx context.Context, d *entity.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uint) (*entity.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, webhookID uint, status entity.WebhookDeliveryStatus, limit int) ([]entity.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+}
+
+type webhookRepository struct{ db *gorm.DB }
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, w *entity.Webhook) error {
+	err := r.db.WithContext(ctx).Create(w).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "webhook create error", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, w *entity.Webhook) error {
+	err := r.db.WithContext(ctx).Model(&entity.Webhook{}).
+		Where("id = ?", w.ID).
+		Updates(map[string]interface{}{
+			"url":        w.URL,
+			"secret":     w.Secret,
+			"events":     w.Events,
+			"active":     w.Active,
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "webhook update error", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id uint) error {
+	tx := r.db.WithContext(ctx).Delete(&entity.Webhook{}, id)
+	if tx.Error != nil {
+		slog.ErrorContext(ctx, "webhook delete error", "err", tx.Error)
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id uint) (*entity.Webhook, error) {
+	var w entity.Webhook
+	if err := r.db.WithContext(ctx).First(&w, id).Error; err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *webhookRepository) List(ctx context.Context) ([]entity.Webhook, error) {
+	var rows []entity.Webhook
+	if err := r.db.WithContext(ctx).Order("id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *webhookRepository) ListActiveForEvent(ctx context.Context, eventType string) ([]entity.Webhook, error) {
+	var rows []entity.Webhook
+	err := r.db.WithContext(ctx).
+		Where("active = ?", true).
+		// events virgülle ayrılmış tutuluyor; baştaki/sondaki virgüller tam eşleşme sağlar
+		Where("events = ? OR ',' || REPLACE(events, ' ', '') || ',' LIKE ?", entity.WebhookEventAll, "%,"+eventType+",%").
+		Find(&rows).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "webhook listActiveForEvent error", "err", err)
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, ds []entity.WebhookDelivery) error {
+	if len(ds) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Create(&ds).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "webhook delivery create error", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) SaveDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	d.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(d).Error
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id uint) (*entity.WebhookDelivery, error) {
+	var d entity.WebhookDelivery
+	if err := r.db.WithContext(ctx).First(&d, id).Error; err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID uint, status entity.WebhookDeliveryStatus, limit int) ([]entity.WebhookDelivery, error) {
+	var rows []entity.WebhookDelivery
+	if limit <= 0 {
+		limit = 100
+	}
+	q := r.db.WithContext(ctx).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	if err := q.Order("created_at DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// ClaimDueDeliveries, zamanı gelmiş teslimatları kilitleyip next_attempt_at'i lease kadar ileri atar.
+// SKIP LOCKED sayesinde birden fazla instance aynı teslimatı iki kez göndermez.
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	var rows []entity.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.DeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		ids := make([]uint, len(rows))
+		for i := range rows {
+			ids[i] = rows[i].ID
+		}
+		return tx.Model(&entity.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "webhook claimDueDeliveries error", "err", err)
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	analyticsDateLayout = "2006-01-02"
+	analyticsMaxDays    = 366
+)
+
+type AnalyticsService interface {
+	RecordView(ctx context.Context, blogID, authorID uint, viewerKey string) error
+	AuthorReport(ctx context.Context, username, from, to string) (*viewmodel.AnalyticsReportVM, error)
+	SiteReport(ctx context.Context, adminUsername, author, from, to string) (*viewmodel.AnalyticsReportVM, error)
+}
+
+type analyticsService struct {
+	anr repository.AnalyticsRepository
+	br  repository.BlogRepository
+	ur  repository.UserRepository
+	cfg config.AnalyticsConfig
+}
+
+func NewAnalyticsService(anr repository.AnalyticsRepository, br repository.BlogRepository, ur repository.UserRepository, cfg config.AnalyticsConfig) AnalyticsService {
+	return &analyticsService{anr: anr, br: br, ur: ur, cfg: cfg}
+}
+
+// RecordView, aynı okuyucu son ViewWindow süresi içinde tekrar okursa sayılmaz. Yazarın kendi
+// okumaları da sayılmaz.
+func (s *analyticsService) RecordView(ctx context.Context, blogID, authorID uint, viewerKey string) error {
+	ctx, span := tracer.Start(ctx, "AnalyticsService.RecordView")
+	defer span.End()
+
+	if blogID == 0 || viewerKey == "" {
+		return Validation("invalid_view", "invalid view")
+	}
+	if viewerKey == UserViewerKey(authorID) {
+		return nil
+	}
+	return s.anr.RecordView(ctx, blogID, viewerKey, time.Now(), s.cfg.ViewWindow)
+}
+
+func (s *analyticsService) AuthorReport(ctx context.Context, username, from, to string) (*viewmodel.AnalyticsReportVM, error) {
+	ctx, span := tracer.Start(ctx, "AnalyticsService.AuthorReport")
+	defer span.End()
+
+	if username == "" {
+		return nil, ErrInvalidUser
+	}
+	return s.report(ctx, username, from, to)
+}
+
+func (s *analyticsService) SiteReport(ctx context.Context, adminUsername, author, from, to string) (*viewmodel.AnalyticsReportVM, error) {
+	ctx, span := tracer.Start(ctx, "AnalyticsService.SiteReport")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, adminUsername)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Role != "admin" {
+		return nil, Forbidden("admin_only", "only admin can view site analytics")
+	}
+	return s.report(ctx, author, from, to)
+}
+
+func (s *analyticsService) report(ctx context.Context, author, fromStr, toStr string) (*viewmodel.AnalyticsReportVM, error) {
+	from, to, err := parseDateRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+	// to tarihi dahil olsun diye sorgularda bir sonraki günün başı kullanılıyor
+	end := to.AddDate(0, 0, 1)
+
+	views, err := s.anr.ViewsByBlog(ctx, author, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("analytics views error: %w", err)
+	}
+	uniques, err := s.anr.UniqueReadersByBlog(ctx, author, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("analytics unique readers error: %w", err)
+	}
+	reactions, err := s.anr.ReactionsByBlog(ctx, author, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("analytics reactions error: %w", err)
+	}
+	comments, err := s.anr.CommentsByBlog(ctx, author, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("analytics comments error: %w", err)
+	}
+	totalUnique, err := s.anr.UniqueReaders(ctx, author, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("analytics unique readers error: %w", err)
+	}
+	daily, err := s.anr.Daily(ctx, author, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("analytics daily error: %w", err)
+	}
+
+	rows := map[uint]*viewmodel.BlogAnalyticsVM{}
+	row := func(id uint) *viewmodel.BlogAnalyticsVM {
+		if r, ok := rows[id]; ok {
+			return r
+		}
+		r := &viewmodel.BlogAnalyticsVM{BlogID: id}
+		rows[id] = r
+		return r
+	}
+
+	report := &viewmodel.AnalyticsReportVM{
+		From:   from.Format(analyticsDateLayout),
+		To:     to.Format(analyticsDateLayout),
+		Author: author,
+		Daily:  make([]viewmodel.DailyViewsVM, 0, len(daily)),
+	}
+	for _, c := range views {
+		row(c.BlogID).Views = c.Count
+		report.Totals.Views += c.Count
+	}
+	for _, c := range uniques {
+		row(c.BlogID).UniqueReaders = c.Count
+	}
+	for _, c := range reactions {
+		row(c.BlogID).Reactions = c.Count
+		report.Totals.Reactions += c.Count
+	}
+	for _, c := range comments {
+		row(c.BlogID).Comments = c.Count
+		report.Totals.Comments += c.Count
+	}
+	report.Totals.UniqueReaders = totalUnique
+
+	for _, d := range daily {
+		report.Daily = append(report.Daily, viewmodel.DailyViewsVM{
+			Date:          d.Day.Format(analyticsDateLayout),
+			Views:         d.Views,
+			UniqueReaders: d.UniqueReaders,
+		})
+	}
+
+	ids := make([]uint, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	blogs, err := s.br.GetByIDsIncludeDeleted(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("analytics blogs error: %w", err)
+	}
+	for _, b := range blogs {
+		r := rows[b.ID]
+		r.Title = b.Content.Title
+		r.Username = b.Content.Username
+	}
+
+	report.Blogs = make([]viewmodel.BlogAnalyticsVM, 0, len(rows))
+	for _, r := range rows {
+		report.Blogs = append(report.Blogs, *r)
+	}
+	sort.Slice(report.Blogs, func(i, j int) bool {
+		if report.Blogs[i].Views != report.Blogs[j].Views {
+			return report.Blogs[i].Views > report.Blogs[j].Views
+		}
+		return report.Blogs[i].BlogID < report.Blogs[j].BlogID
+	})
+	return report, nil
+}
+
+// UserViewerKey, giriş yapmış okuyucular kullanıcı adı değişse de aynı sayılsın diye id ile tutulur
+func UserViewerKey(userID uint) string {
+	return "u:" + strconv.FormatUint(uint64(userID), 10)
+}
+
+// parseDateRange: from/to "YYYY-MM-DD", boşsa son 30 gün
+func parseDateRange(fromStr, toStr string) (time.Time, time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	to := today
+	if toStr != "" {
+		t, err := time.ParseInLocation(analyticsDateLayout, toStr, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, Validation("invalid_date", "invalid to date, expected YYYY-MM-DD").With("field", "to")
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -29)
+	if fromStr != "" {
+		f, err := time.ParseInLocation(analyticsDateLayout, fromStr, now.Location())
+		if err != nil {
+			return time.Time{}, time.Time{}, Validation("invalid_date", "invalid from date, expected YYYY-MM-DD").With("field", "from")
+		}
+		from = f
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, Validation("invalid_date_range", "from date must be before to date")
+	}
+	if to.Sub(from) > analyticsMaxDays*24*time.Hour {
+		return time.Time{}, time.Time{}, Validation("date_range_too_long", "date range is too long")
+	}
+	return from, to, nil
+}
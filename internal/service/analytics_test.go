@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.ParseInLocation(analyticsDateLayout, s, time.Local)
+		return d
+	}
+	tests := []struct {
+		from, to         string
+		wantFrom, wantTo time.Time
+		wantCode         string
+	}{
+		{from: "2024-01-01", to: "2024-01-31", wantFrom: day("2024-01-01"), wantTo: day("2024-01-31")},
+		{to: "2024-03-30", wantFrom: day("2024-03-01"), wantTo: day("2024-03-30")},
+		{from: "2024-01-01", to: "2024-01-01", wantFrom: day("2024-01-01"), wantTo: day("2024-01-01")},
+		{from: "2024-02-01", to: "2024-01-01", wantCode: "invalid_date_range"},
+		{from: "2023-01-01", to: "2024-12-31", wantCode: "date_range_too_long"},
+		{from: "01/02/2024", to: "2024-01-31", wantCode: "invalid_date"},
+		{to: "yesterday", wantCode: "invalid_date"},
+	}
+	for _, tt := range tests {
+		from, to, err := parseDateRange(tt.from, tt.to)
+		if tt.wantCode != "" {
+			var e *Error
+			if !errors.As(err, &e) || e.Code != tt.wantCode {
+				t.Errorf("parseDateRange(%q, %q) err = %v, want %s", tt.from, tt.to, err, tt.wantCode)
+			}
+			continue
+		}
+		if err != nil || !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+			t.Errorf("parseDateRange(%q, %q) = %v, %v, %v", tt.from, tt.to, from, to, err)
+		}
+	}
+}
+
+func TestParseDateRangeDefault(t *testing.T) {
+	from, to, err := parseDateRange("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if to.Hour() != 0 || to.After(time.Now()) || from.AddDate(0, 0, 29) != to {
+		t.Errorf("default range = %v .. %v, want last 30 days", from, to)
+	}
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	archiveMaxFiles    = 500
+	archiveMaxFileSize = 2 << 20 // 2 MB, tek bir markdown dosyası için
+)
+
+var frontMatterDelim = []byte("---")
+
+type ArchiveService interface {
+	// ExportBlogs, kullanıcının bloglarını yükler ve zip'i yazacak fonksiyonu döner
+	ExportBlogs(ctx context.Context, username string) (func(w io.Writer) error, error)
+	// ImportBlogs, zip içindeki .md dosyalarını onaysız taslak blog olarak oluşturur
+	// locale, rapordaki dosya hatalarının dili
+	ImportBlogs(ctx context.Context, username, locale string, data []byte) (*viewmodel.ImportReportVM, error)
+}
+
+type archiveService struct {
+	bs BlogService
+	br repository.BlogRepository
+	ur repository.UserRepository
+}
+
+func NewArchiveService(bs BlogService, br repository.BlogRepository, ur repository.UserRepository) ArchiveService {
+	return &archiveService{bs: bs, br: br, ur: ur}
+}
+
+// frontMatter, export edilen .md dosyalarının başındaki YAML bloğu
+type frontMatter struct {
+	Title      string     `yaml:"title"`
+	Type       string     `yaml:"type,omitempty"`
+	Tags       tagList    `yaml:"tags"`
+	Category   string     `yaml:"category"`
+	Status     string     `yaml:"status"`
+	BodyFormat string     `yaml:"body_format,omitempty"`
+	CreatedAt  *time.Time `yaml:"created_at,omitempty"`
+	UpdatedAt  *time.Time `yaml:"updated_at,omitempty"`
+}
+
+// tagList, hem YAML listesini hem de "go, fiber" gibi virgüllü metni kabul eder
+type tagList []string
+
+func (t *tagList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = splitTags(node.Value)
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+func splitTags(s string) []string {
+	var out []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+func (s *archiveService) ExportBlogs(ctx context.Context, username string) (func(w io.Writer) error, error) {
+	ctx, span := tracer.Start(ctx, "ArchiveService.ExportBlogs")
+	defer span.End()
+
+	if _, err := s.ur.GetByUsername(ctx, username); err != nil {
+		return nil, ErrUserNotFound
+	}
+	blogs, err := s.br.GetBlogsByAuthor(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("blogs get error: %w", err)
+	}
+	sort.Slice(blogs, func(i, j int) bool { return blogs[i].CreatedAt.Before(blogs[j].CreatedAt) })
+
+	return func(w io.Writer) error {
+		zw := zip.NewWriter(w)
+		used := map[string]int{}
+		for i := range blogs {
+			name := archiveFileName(&blogs[i], used)
+			f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: blogs[i].UpdatedAt})
+			if err != nil {
+				return err
+			}
+			if err := writeMarkdown(f, &blogs[i]); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	}, nil
+}
+
+func archiveFileName(b *entity.Blog, used map[string]int) string {
+	base := slugify(b.Content.Title)
+	if base == "" {
+		base = fmt.Sprintf("blog-%d", b.ID)
+	}
+	n := used[base]
+	used[base] = n + 1
+	if n > 0 {
+		base = fmt.Sprintf("%s-%d", base, n+1)
+	}
+	return base + ".md"
+}
+
+func writeMarkdown(w io.Writer, b *entity.Blog) error {
+	created, updated := b.CreatedAt, b.UpdatedAt
+	fm := frontMatter{
+		Title:      b.Content.Title,
+		Type:       b.Content.Type,
+		Tags:       splitTags(b.Tags),
+		Category:   b.Category,
+		Status:     b.Content.Status,
+		BodyFormat: b.Content.BodyFormat,
+		CreatedAt:  &created,
+		UpdatedAt:  &updated,
+	}
+	head, err := yaml.Marshal(&fm)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.Write(frontMatterDelim)
+	buf.WriteByte('\n')
+	buf.Write(head)
+	buf.Write(frontMatterDelim)
+	buf.WriteString("\n\n")
+	buf.WriteString(b.Content.Body)
+	if !strings.HasSuffix(b.Content.Body, "\n") {
+		buf.WriteByte('\n')
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func (s *archiveService) ImportBlogs(ctx context.Context, username, locale string, data []byte) (*viewmodel.ImportReportVM, error) {
+	ctx, span := tracer.Start(ctx, "ArchiveService.ImportBlogs")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	// CreateBlog da kontrol ediyor; burada tüm arşivi boşuna işlememek için erken dönülür
+	if user.Role != "admin" && user.Role != "writer" {
+		return nil, Forbidden("blog_create_forbidden", "User is not authorized to create a blog")
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, Validation("invalid_archive", "invalid zip archive")
+	}
+	if len(zr.File) > archiveMaxFiles {
+		return nil, Validation("archive_too_many_files", fmt.Sprintf("archive has too many files, max %d", archiveMaxFiles)).
+			With("max", strconv.Itoa(archiveMaxFiles))
+	}
+
+	report := &viewmodel.ImportReportVM{Files: []viewmodel.ImportFileResultVM{}}
+	for _, f := range zr.File {
+		res := s.importFile(ctx, username, locale, f)
+		switch res.Status {
+		case viewmodel.ImportStatusCreated:
+			report.Created++
+		case viewmodel.ImportStatusFailed:
+			report.Failed++
+		default:
+			report.Skipped++
+		}
+		report.Files = append(report.Files, res)
+	}
+	return report, nil
+}
+
+func (s *archiveService) importFile(ctx context.Context, username, locale string, f *zip.File) viewmodel.ImportFileResultVM {
+	res := viewmodel.ImportFileResultVM{File: f.Name}
+	base := path.Base(f.Name)
+	if f.FileInfo().IsDir() || strings.HasPrefix(base, ".") || strings.HasPrefix(f.Name, "__MACOSX/") {
+		res.Status = viewmodel.ImportStatusSkipped
+		return res
+	}
+	if !strings.EqualFold(path.Ext(base), ".md") {
+		res.Status = viewmodel.ImportStatusSkipped
+		res.Error = "not a markdown file"
+		return res
+	}
+	fail := func(err error) viewmodel.ImportFileResultVM {
+		res.Status = viewmodel.ImportStatusFailed
+		res.Error = Localize(locale, err)
+		return res
+	}
+	tooLarge := Validation("file_too_large", fmt.Sprintf("file is too large, max %d bytes", archiveMaxFileSize)).
+		With("max", strconv.Itoa(archiveMaxFileSize))
+	unreadable := Validation("file_unreadable", "file could not be read")
+	if f.UncompressedSize64 > archiveMaxFileSize {
+		return fail(tooLarge)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fail(unreadable)
+	}
+	// başlıktaki boyut yalan olabilir, okurken de sınırla
+	raw, err := io.ReadAll(io.LimitReader(rc, archiveMaxFileSize+1))
+	rc.Close()
+	if err != nil {
+		return fail(unreadable)
+	}
+	if len(raw) > archiveMaxFileSize {
+		return fail(tooLarge)
+	}
+
+	fm, body, err := parseMarkdown(raw)
+	if err != nil {
+		return fail(err)
+	}
+	res.Title = fm.Title
+
+	format := fm.BodyFormat
+	if format == "" {
+		format = entity.BodyFormatMarkdown
+	}
+	vm := &viewmodel.BlogCreateVM{
+		Title:      strings.TrimSpace(fm.Title),
+		Body:       body,
+		BodyFormat: format,
+		Type:       fm.Type,
+		Tags:       strings.Join(fm.Tags, ","),
+		Category:   fm.Category,
+		Status:     entity.BlogStatusDraft, // import edilen her şey taslak olarak gelir
+	}
+	if err := Validate(vm); err != nil {
+		return fail(err)
+	}
+	if err := s.bs.ImportBlog(ctx, vm, username); err != nil {
+		return fail(err)
+	}
+	res.Status = viewmodel.ImportStatusCreated
+	return res
+}
+
+// parseMarkdown, "---" ile sınırlanan YAML front matter'ı ve gövdeyi ayırır
+func parseMarkdown(raw []byte) (*frontMatter, string, error) {
+	raw = bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf")) // UTF-8 BOM
+	raw = bytes.ReplaceAll(raw, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(raw, append(frontMatterDelim, '\n')) {
+		return nil, "", errors.New("missing YAML front matter")
+	}
+	rest := raw[len(frontMatterDelim)+1:]
+	end := bytes.Index(rest, append([]byte("\n"), frontMatterDelim...))
+	if end < 0 {
+		return nil, "", errors.New("unterminated YAML front matter")
+	}
+	head := rest[:end+1]
+	body := rest[end+1+len(frontMatterDelim):]
+	if len(body) > 0 && body[0] != '\n' {
+		return nil, "", errors.New("unterminated YAML front matter")
+	}
+
+	var fm frontMatter
+	if err := yaml.Unmarshal(head, &fm); err != nil {
+		return nil, "", fmt.Errorf("invalid front matter: %v", err)
+	}
+	if strings.TrimSpace(fm.Title) == "" {
+		return nil, "", errors.New("front matter title is required")
+	}
+	return &fm, strings.TrimSpace(string(body)), nil
+}
@@ -3,11 +3,13 @@ package service
 import (
 	"cleanArch_with_postgres/internal/entity"
 	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/infrastructure/eventbus"
+	"cleanArch_with_postgres/internal/infrastructure/metrics"
 	"cleanArch_with_postgres/internal/repository"
 	"cleanArch_with_postgres/internal/viewmodel"
 	"context"
-	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -16,6 +18,10 @@ import (
 
 type AuthService interface {
 	Register(ctx context.Context, vm viewmodel.RegisterRequest) (*viewmodel.RegisterResponse, error)
+	// CreateUser, operatörün (CLI) kullanıcı açması; Register'dan farklı olarak admin rolü korunur
+	CreateUser(ctx context.Context, vm viewmodel.RegisterRequest) (*viewmodel.RegisterResponse, error)
+	// ResetPassword, şifreyi operatör olarak değiştirir; mustReset ise kullanıcı şifresini değiştirene kadar sadece /me'yi kullanabilir
+	ResetPassword(ctx context.Context, username, password string, mustReset bool) error
 	Login(ctx context.Context, identifier, password string) (*viewmodel.LoginResponse, error)
 	GetUserVMByUsername(ctx context.Context, paramUsername, tokenUsername string) (*viewmodel.UserVM, error)
 	SearchUsers(ctx context.Context, prefix string, limit int) ([]viewmodel.UserVM, error)
@@ -33,22 +39,42 @@ type authService struct {
 	ur repository.UserRepository
 	br repository.BlogRepository
 	rr repository.RoleRequestRepository
+	ns NotificationService
+	wh WebhookService
 }
 
-func NewAuthService(ur repository.UserRepository, br repository.BlogRepository, rr repository.RoleRequestRepository) AuthService {
-	return &authService{ur: ur, br: br, rr: rr}
+func NewAuthService(ur repository.UserRepository, br repository.BlogRepository, rr repository.RoleRequestRepository, ns NotificationService, wh WebhookService) AuthService {
+	return &authService{ur: ur, br: br, rr: rr, ns: ns, wh: wh}
 }
 
 func (s *authService) Register(ctx context.Context, vm viewmodel.RegisterRequest) (*viewmodel.RegisterResponse, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.Register")
+	defer span.End()
+
+	return s.register(ctx, vm, false)
+}
+
+func (s *authService) CreateUser(ctx context.Context, vm viewmodel.RegisterRequest) (*viewmodel.RegisterResponse, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.CreateUser")
+	defer span.End()
+
+	if err := Validate(&vm); err != nil {
+		return nil, err
+	}
+	return s.register(ctx, vm, true)
+}
+
+func (s *authService) register(ctx context.Context, vm viewmodel.RegisterRequest, allowAdmin bool) (*viewmodel.RegisterResponse, error) {
 	user := &entity.User{
 		Email:    vm.Email,
 		Username: vm.Username,
 		Password: vm.Password,
 		Role:     entity.UserRole(vm.Role),
+		Locale:   strings.ToLower(vm.Locale),
 	}
 
 	if user == nil {
-		return nil, errors.New("user is nil")
+		return nil, ErrInvalidInput
 	}
 
 	exist, err := s.ur.ExistUser(ctx, vm.Email, vm.Username)
@@ -56,7 +82,7 @@ func (s *authService) Register(ctx context.Context, vm viewmodel.RegisterRequest
 		return nil, err
 	}
 	if exist {
-		return nil, errors.New("email or username already exists")
+		return nil, ErrUserExists
 	}
 
 	hashed, err := bcrypt.GenerateFromPassword([]byte(vm.Password), bcrypt.DefaultCost)
@@ -66,7 +92,7 @@ func (s *authService) Register(ctx context.Context, vm viewmodel.RegisterRequest
 	user.Password = string(hashed)
 
 	if user.Role == "" {
-		return nil, errors.New("lütfen bir rol seçiniz: reader, writer, admin")
+		return nil, Validation("role_required", "lütfen bir rol seçiniz: reader, writer, admin")
 	}
 
 	if user.Role == "writer" {
@@ -76,7 +102,7 @@ func (s *authService) Register(ctx context.Context, vm viewmodel.RegisterRequest
 		user.Role = entity.RoleReader
 	}
 
-	if user.Role == "admin" { // *************ADMİN ONAYI İÇİN BİLDİRİM MEKANİZMASI YAP************* //
+	if user.Role == "admin" && !allowAdmin { // *************ADMİN ONAYI İÇİN BİLDİRİM MEKANİZMASI YAP************* //
 		user.Role = entity.RoleReader
 	}
 
@@ -91,7 +117,30 @@ func (s *authService) Register(ctx context.Context, vm viewmodel.RegisterRequest
 		Role:     string(user.Role),
 	}
 
-	return resp, s.ur.Create(ctx, user)
+	if err := s.ur.Create(ctx, user); err != nil {
+		return resp, err
+	}
+	metrics.Registrations.Inc()
+	dispatchWebhook(ctx, s.wh, entity.WebhookEventUserRegistered, map[string]interface{}{
+		"id":         user.ID,
+		"username":   user.Username,
+		"email":      user.Email,
+		"role":       user.Role,
+		"created_at": user.CreatedAt,
+	})
+	return resp, nil
+}
+
+var (
+	ErrUserExists         = Conflict("user_exists", "email or username already exists")
+	ErrInvalidCredentials = Unauthorized("invalid_credentials", "invalid username or password")
+)
+
+func roleRequestLookupError(err error) error {
+	if repository.IsNotFound(err) {
+		return NotFound("role_request_not_found", "role request not found")
+	}
+	return err
 }
 
 type accessToken struct {
@@ -99,22 +148,31 @@ type accessToken struct {
 	Username string `json:"username"`
 	UserID   uint   `json:"user_id"`
 	Role     string `json:"role"`
+	Locale   string `json:"locale,omitempty"`
 	Exp      int64  `json:"exp"`
+
+	MustResetPassword bool `json:"must_reset_password,omitempty"`
 }
 
 func (s *authService) Login(ctx context.Context, identifier, password string) (*viewmodel.LoginResponse, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.Login")
+	defer span.End()
+
 	user, err := s.ur.GetByIdentifier(ctx, identifier)
-	if user == nil || err != nil { // ***
-		return nil, errors.New("user not found")
+	if user == nil || err != nil { // *** kullanıcı var mı yok mu belli etmemek için şifre hatasıyla aynı
+		metrics.Logins.WithLabelValues("failure").Inc()
+		return nil, ErrInvalidCredentials
 	}
 
 	if user.DeletedAt.Valid {
-		return nil, errors.New("user is deleted")
+		metrics.Logins.WithLabelValues("failure").Inc()
+		return nil, ErrUserDeleted
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, errors.New("invalid password")
+		metrics.Logins.WithLabelValues("failure").Inc()
+		return nil, ErrInvalidCredentials
 	}
 
 	// jwtSecret := os.Getenv("JWT_SECRET") // config'den çek ***
@@ -123,34 +181,43 @@ func (s *authService) Login(ctx context.Context, identifier, password string) (*
 		Username: user.Username,
 		UserID:   user.ID,
 		Role:     string(user.Role),
+		Locale:   user.Locale,
 		Exp:      time.Now().Add(time.Hour * 24).Unix(),
+
+		MustResetPassword: user.MustResetPassword,
 	}).SignedString([]byte(jwtSecret))
 	if err != nil {
-		return nil, errors.New("failed to generate token")
+		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
 	resp := &viewmodel.LoginResponse{
-		Token:    token,
-		ID:       user.ID,
-		Username: user.Username,
-		Email:    user.Email,
-		Role:     string(user.Role),
+		Token:             token,
+		ID:                user.ID,
+		Username:          user.Username,
+		Email:             user.Email,
+		Role:              string(user.Role),
+		Locale:            user.Locale,
+		MustResetPassword: user.MustResetPassword,
 	}
+	metrics.Logins.WithLabelValues("success").Inc()
 
 	return resp, nil
 }
 
 func (s *authService) GetUserVMByUsername(ctx context.Context, paramUsername, tokenUsername string) (*viewmodel.UserVM, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.GetUserVMByUsername")
+	defer span.End()
+
 	if paramUsername == "" {
-		return nil, errors.New("invalid username")
+		return nil, ErrInvalidUser
 	}
 
 	tokenUser, err := s.ur.GetByUsername(ctx, paramUsername)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 	if tokenUser.DeletedAt.Valid {
-		return nil, errors.New("The logged in user has been deleted")
+		return nil, ErrUserDeleted
 	}
 
 	user, err := s.ur.GetByUsername(ctx, paramUsername)
@@ -163,6 +230,9 @@ func (s *authService) GetUserVMByUsername(ctx context.Context, paramUsername, to
 
 // implementasyon:
 func (s *authService) SearchUsers(ctx context.Context, prefix string, limit int) ([]viewmodel.UserVM, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.SearchUsers")
+	defer span.End()
+
 	if len(prefix) == 0 {
 		return []viewmodel.UserVM{}, nil
 	}
@@ -178,6 +248,9 @@ func (s *authService) SearchUsers(ctx context.Context, prefix string, limit int)
 }
 
 func (s *authService) SearchUsersWithOptions(ctx context.Context, viewerUsername, prefix string, limit int, includeDeleted bool) ([]viewmodel.UserVM, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.SearchUsersWithOptions")
+	defer span.End()
+
 	if len(prefix) == 0 {
 		return []viewmodel.UserVM{}, nil
 	}
@@ -185,7 +258,7 @@ func (s *authService) SearchUsersWithOptions(ctx context.Context, viewerUsername
 	// viewer’ı çek → admin mi?
 	viewer, err := s.ur.GetByUsername(ctx, viewerUsername)
 	if err != nil {
-		return nil, errors.New("viewer not found")
+		return nil, ErrUserNotFound
 	}
 
 	// admin değilse silinmişleri gösterme
@@ -206,31 +279,67 @@ func (s *authService) SearchUsersWithOptions(ctx context.Context, viewerUsername
 }
 
 func (s *authService) RestoreUser(ctx context.Context, username string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.RestoreUser")
+	defer span.End()
+
 	if username == "" {
-		return errors.New("invalid username")
+		return ErrInvalidUser
 	}
 
 	if err := s.ur.Restore(ctx, username); err != nil {
+		if repository.IsNotFound(err) {
+			return ErrUserNotFound
+		}
 		return err
 	}
 	return nil
 }
 
+func (s *authService) ResetPassword(ctx context.Context, username, password string, mustReset bool) error {
+	ctx, span := tracer.Start(ctx, "AuthService.ResetPassword")
+	defer span.End()
+
+	if username == "" {
+		return ErrInvalidUser
+	}
+	if err := Validate(&viewmodel.PasswordResetRequest{Password: password}); err != nil {
+		return err
+	}
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if user.DeletedAt.Valid {
+		return ErrUserDeleted
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.Password = string(hashed)
+	user.MustResetPassword = mustReset
+	return s.ur.Update(ctx, user.Username, user)
+}
+
 func (s *authService) UpdateUser(ctx context.Context, username string, vm *viewmodel.UpdateRequest) (*viewmodel.UpdateResponse, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.UpdateUser")
+	defer span.End()
+
 	if vm == nil {
-		return nil, errors.New("user is nil")
+		return nil, ErrInvalidInput
 	}
 
 	if vm.Password != "" {
 		hashed, err := bcrypt.GenerateFromPassword([]byte(vm.Password), bcrypt.DefaultCost)
 		if err != nil {
-			return nil, errors.New("failed to hash password")
+			return nil, fmt.Errorf("failed to hash password: %w", err)
 		}
 		vm.Password = string(hashed)
 	}
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 
 	existUser, err := s.ur.ExistUser(ctx, vm.Email, vm.Username)
@@ -238,13 +347,13 @@ func (s *authService) UpdateUser(ctx context.Context, username string, vm *viewm
 		return nil, err
 	}
 	if existUser {
-		return nil, errors.New("email or username already exists")
+		return nil, ErrUserExists
 	}
 
 	oldUsername := user.Username
 
 	if user.DeletedAt.Valid {
-		return nil, errors.New("user is deleted")
+		return nil, ErrUserDeleted
 	}
 	if vm.Username != "" {
 		user.Username = vm.Username
@@ -254,13 +363,18 @@ func (s *authService) UpdateUser(ctx context.Context, username string, vm *viewm
 	}
 	if vm.Password != "" {
 		user.Password = vm.Password
+		user.MustResetPassword = false
+	}
+	if vm.Locale != "" {
+		// token'daki dil bir sonraki girişte güncellenir
+		user.Locale = strings.ToLower(vm.Locale)
 	}
 	user.UpdatedAt = time.Now()
 
 	if oldUsername != user.Username {
 		err := s.br.UpdateAuthorUsername(ctx, oldUsername, user.Username)
 		if err != nil {
-			return nil, errors.New("failed to author username in blogs")
+			return nil, fmt.Errorf("failed to author username in blogs: %w", err)
 		}
 	}
 
@@ -269,6 +383,7 @@ func (s *authService) UpdateUser(ctx context.Context, username string, vm *viewm
 		Username:  user.Username,
 		Email:     user.Email,
 		Role:      string(user.Role),
+		Locale:    user.Locale,
 		UpdatedAt: user.UpdatedAt,
 	}
 
@@ -276,17 +391,20 @@ func (s *authService) UpdateUser(ctx context.Context, username string, vm *viewm
 }
 
 func (s *authService) DeleteUser(ctx context.Context, username string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.DeleteUser")
+	defer span.End()
+
 	if username == "" {
-		return errors.New("Invalid username")
+		return ErrInvalidUser
 	}
 
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return errors.New("user not found")
+		return ErrUserNotFound
 	}
 
 	if user.DeletedAt.Valid {
-		return errors.New("user is deleted")
+		return ErrUserDeleted
 	}
 
 	return s.ur.Delete(ctx, username)
@@ -294,12 +412,15 @@ func (s *authService) DeleteUser(ctx context.Context, username string) error {
 
 // admin onayı için role request servisleri
 func (s *authService) RequestAdminRole(ctx context.Context, username, reason string) (*viewmodel.RoleRequestVM, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.RequestAdminRole")
+	defer span.End()
+
 	if username == "" {
-		return nil, errors.New("invalid username")
+		return nil, ErrInvalidUser
 	}
 
 	if last, err := s.rr.LatestByUser(ctx, username); err == nil && last != nil && last.Status == entity.RoleReqPending {
-		return nil, errors.New("zaten bekleyen bir talebiniz var")
+		return nil, Conflict("role_request_pending", "zaten bekleyen bir talebiniz var")
 	}
 	rr := &entity.RoleRequest{
 		Username:      username,
@@ -312,10 +433,16 @@ func (s *authService) RequestAdminRole(ctx context.Context, username, reason str
 	if err := s.rr.Create(ctx, rr); err != nil {
 		return nil, err
 	}
-	return viewmodel.ToRoleReqVM(rr), nil
+	metrics.RoleRequests.WithLabelValues("requested").Inc()
+	vm := viewmodel.ToRoleReqVM(rr)
+	_ = s.ns.NotifyAdmins(ctx, eventbus.RoleRequestCreated, vm)
+	return vm, nil
 }
 
 func (s *authService) ListRoleRequests(ctx context.Context, status string, limit int) ([]viewmodel.RoleRequestVM, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.ListRoleRequests")
+	defer span.End()
+
 	var st entity.RoleRequestStatus
 	switch status {
 	case "pending":
@@ -327,7 +454,7 @@ func (s *authService) ListRoleRequests(ctx context.Context, status string, limit
 	case "", "all":
 		st = "" // repo tarafı tümünü getirir
 	default:
-		return nil, errors.New("invalid status")
+		return nil, Validation("invalid_status", "invalid status")
 	}
 
 	rows, err := s.rr.List(ctx, st, limit)
@@ -339,17 +466,21 @@ func (s *authService) ListRoleRequests(ctx context.Context, status string, limit
 }
 
 func (s *authService) ApproveRoleRequest(ctx context.Context, id uint, adminUsername string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.ApproveRoleRequest")
+	defer span.End()
+
 	if id == 0 {
-		return errors.New("invalid id")
+		return ErrInvalidID
 	}
 
 	if err := s.rr.Approve(ctx, id, adminUsername); err != nil {
 		return err
 	}
+	metrics.RoleRequests.WithLabelValues("approved").Inc()
 
 	rr, err := s.rr.GetByID(ctx, id)
 	if err != nil {
-		return err
+		return roleRequestLookupError(err)
 	}
 	u, err := s.ur.GetByUsername(ctx, rr.Username)
 	if err != nil {
@@ -360,12 +491,28 @@ func (s *authService) ApproveRoleRequest(ctx context.Context, id uint, adminUser
 	if err := s.ur.Update(ctx, u.Username, u); err != nil {
 		return err
 	}
+	_ = s.ns.Notify(ctx, rr.Username, "role_request.decision", "role_request_approved", nil, "/me")
+	dispatchWebhook(ctx, s.wh, entity.WebhookEventRoleRequestDecided, viewmodel.ToRoleReqVM(rr))
 	return nil
 }
 
 func (s *authService) RejectRoleRequest(ctx context.Context, id uint, adminUsername string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.RejectRoleRequest")
+	defer span.End()
+
 	if id == 0 {
-		return errors.New("invalid id")
+		return ErrInvalidID
 	}
-	return s.rr.Reject(ctx, id, adminUsername)
+	if err := s.rr.Reject(ctx, id, adminUsername); err != nil {
+		return err
+	}
+	metrics.RoleRequests.WithLabelValues("rejected").Inc()
+
+	rr, err := s.rr.GetByID(ctx, id)
+	if err != nil {
+		return roleRequestLookupError(err)
+	}
+	_ = s.ns.Notify(ctx, rr.Username, "role_request.decision", "role_request_rejected", nil, "/me")
+	dispatchWebhook(ctx, s.wh, entity.WebhookEventRoleRequestDecided, viewmodel.ToRoleReqVM(rr))
+	return nil
 }
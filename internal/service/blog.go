@@ -2,15 +2,23 @@ package service
 
 import (
 	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/eventbus"
+	"cleanArch_with_postgres/internal/infrastructure/metrics"
+	"cleanArch_with_postgres/internal/infrastructure/render"
 	"cleanArch_with_postgres/internal/repository"
 	"cleanArch_with_postgres/internal/viewmodel"
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
 	"time"
 )
 
 type BlogService interface {
 	CreateBlog(ctx context.Context, blogVM *viewmodel.BlogCreateVM, username string) error
+	// ImportBlog, arşiv import'u için: onaysız oluşturur, webhook ve bildirim göndermez
+	ImportBlog(ctx context.Context, blogVM *viewmodel.BlogCreateVM, username string) error
 	UpdateBlog(ctx context.Context, title, username string, vm *viewmodel.BlogUpdateVM) (*viewmodel.BlogUpdateResponse, error)
 	DeleteBlog(ctx context.Context, title, username string) (string, error)
 	GetAllBlogs(ctx context.Context, username string) ([]viewmodel.BlogVM, error)
@@ -20,62 +28,103 @@ type BlogService interface {
 	GetBlogByTitle(ctx context.Context, title, username string) (*viewmodel.BlogVM, error)
 	ApproveBlog(ctx context.Context, title, username string, approved bool) error
 	RestoreBlog(ctx context.Context, title, username string) error
+	// RenderPending, türetilmiş alanları olmayan eski blogları arka planda işler
+	RenderPending(ctx context.Context) error
 }
 
 type blogService struct {
 	br repository.BlogRepository
 	ur repository.UserRepository
+	ns NotificationService
+	wh WebhookService
+	ms MediaService
 }
 
-func NewBlogService(br repository.BlogRepository, ur repository.UserRepository) BlogService {
-	return &blogService{br: br, ur: ur}
+func NewBlogService(br repository.BlogRepository, ur repository.UserRepository, ns NotificationService, wh WebhookService, ms MediaService) BlogService {
+	return &blogService{br: br, ur: ur, ns: ns, wh: wh, ms: ms}
+}
+
+var (
+	ErrDuplicateBody = Conflict("blog_duplicate_body", "blog with the same body already exists")
+	// onaysız blog, yetkisi olmayanlara hiç yokmuş gibi gösterilir
+	ErrBlogNotApproved = NotFound("blog_not_approved", "blog not found or not approved")
+)
+
+// blogLookupError, repository'nin "kayıt yok" hatasını 404'e çevirir, diğerlerini olduğu gibi bırakır
+func blogLookupError(err error) error {
+	if repository.IsNotFound(err) {
+		return ErrBlogNotFound
+	}
+	return err
+}
+
+func bodyFormatError(err error) error {
+	if errors.Is(err, render.ErrUnknownFormat) {
+		return Validation("invalid_body_format", err.Error())
+	}
+	return err
 }
 
 func (s *blogService) CreateBlog(ctx context.Context, blogVM *viewmodel.BlogCreateVM, username string) error {
+	ctx, span := tracer.Start(ctx, "BlogService.CreateBlog")
+	defer span.End()
+
+	blog, err := s.createBlog(ctx, blogVM, username, false)
+	if err != nil {
+		return err
+	}
+
+	if !blog.Content.IsApproved { // onay bekleyen blogu admin paneline anlık bildir
+		_ = s.ns.NotifyAdmins(ctx, eventbus.BlogPendingApproval, map[string]interface{}{
+			"id":         blog.ID,
+			"title":      blog.Content.Title,
+			"username":   blog.Content.Username,
+			"created_at": blog.CreatedAt,
+		})
+	} else { // admin blogları direkt yayında
+		dispatchWebhook(ctx, s.wh, entity.WebhookEventBlogPublished, blogPublishedPayload(blog))
+	}
+	return nil
+}
+
+// ImportBlog, arşivden gelen yazıyı oluşturur. Yetki ve aynı gövde kontrolleri CreateBlog ile aynıdır,
+// ama yazı admin'in olsa da onaysız kalır; webhook ve admin bildirimi gönderilmez.
+func (s *blogService) ImportBlog(ctx context.Context, blogVM *viewmodel.BlogCreateVM, username string) error {
+	ctx, span := tracer.Start(ctx, "BlogService.ImportBlog")
+	defer span.End()
+
+	_, err := s.createBlog(ctx, blogVM, username, true)
+	return err
+}
+
+// createBlog, kontrolleri yapar ve blogu kaydeder; admin blogları import edilmiyorsa onaylı oluşur
+func (s *blogService) createBlog(ctx context.Context, blogVM *viewmodel.BlogCreateVM, username string, imported bool) (*entity.Blog, error) {
 	if username == "" {
-		return errors.New("Invalid username")
+		return nil, ErrInvalidUser
 	}
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return errors.New("User not found")
+		return nil, ErrUserNotFound
 	}
 	if user.Role != "admin" && user.Role != "writer" {
-		return errors.New("User is not authorized to create a blog")
+		return nil, Forbidden("blog_create_forbidden", "User is not authorized to create a blog")
 	}
 
 	existBlog, err := s.br.ExistBlog(ctx, blogVM.Body) // title kontrol etme
 	if err != nil {
-		return errors.New("create blog exist error")
+		return nil, fmt.Errorf("create blog exist error: %w", err)
 	}
 	if existBlog {
-		return errors.New("blog with the same body already exists")
-	}
-	if blogVM.Title == "" {
-		return errors.New("Blog başlığı boş olamaz")
-	}
-
-	if blogVM.Type == "" {
-		return errors.New("Lütfen blog tipini dolurunuz")
-	}
-
-	if blogVM.Body == "" {
-		return errors.New("İçerik gövdesi boş olamaz")
+		return nil, ErrDuplicateBody
 	}
 
 	if user.ID == 0 {
-		return errors.New("Invalid AuthorID")
-	}
-
-	if blogVM.Status == "" {
-		return errors.New("Lütfen blogun statüsünü doldurunuz")
-	}
-
-	if blogVM.Tags == "" {
-		return errors.New("Blogun tag kısmı boş kalamaz")
+		return nil, Validation("invalid_author_id", "Invalid AuthorID")
 	}
 
-	if blogVM.Category == "" {
-		return errors.New("Blogun kategorisini doğru giriniz")
+	// alan kontrolleri viewmodel tag'lerinde, handler Validate ile çalıştırıyor
+	if err := validateSEO(&blogVM.SEO); err != nil {
+		return nil, err
 	}
 
 	blog := &entity.Blog{
@@ -86,6 +135,7 @@ func (s *blogService) CreateBlog(ctx context.Context, blogVM *viewmodel.BlogCrea
 		Content: entity.Content{
 			Title:      blogVM.Title,
 			Body:       blogVM.Body,
+			BodyFormat: blogVM.BodyFormat,
 			AuthorID:   int(user.ID),
 			Username:   username,
 			Type:       blogVM.Type,
@@ -94,123 +144,174 @@ func (s *blogService) CreateBlog(ctx context.Context, blogVM *viewmodel.BlogCrea
 		},
 		Tags:     blogVM.Tags,
 		Category: blogVM.Category,
+		SEO:      blogVM.SEO.ToEntity(),
 	}
-	if user.Role == "admin" {
+	if user.Role == "admin" && !imported {
 		blog.Content.IsApproved = true
 	}
-	return s.br.Create(ctx, blog)
+	if err := render.Apply(&blog.Content); err != nil {
+		return nil, bodyFormatError(err)
+	}
+	if err := s.br.Create(ctx, blog); err != nil {
+		return nil, err
+	}
+	metrics.BlogsCreated.Inc()
+	s.syncMedia(ctx, blog.ID, blog.Content.Body)
+	return blog, nil
+}
+
+func blogPublishedPayload(b *entity.Blog) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       b.ID,
+		"title":    b.Content.Title,
+		"username": b.Content.Username,
+		"type":     b.Content.Type,
+		"tags":     b.Tags,
+		"category": b.Category,
+		"status":   b.Content.Status,
+	}
 }
 
 func (s *blogService) UpdateBlog(ctx context.Context, title, username string, vm *viewmodel.BlogUpdateVM) (*viewmodel.BlogUpdateResponse, error) {
+	ctx, span := tracer.Start(ctx, "BlogService.UpdateBlog")
+	defer span.End()
+
 	if title == "" {
-		return nil, errors.New("Invalid Title")
+		return nil, ErrInvalidTitle
 	}
 
 	blog, err := s.br.GetBlogByTitle(ctx, title)
 	if err != nil {
-		return nil, errors.New("blog not found")
+		return nil, blogLookupError(err)
 	}
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 
 	// admini öne koydum ve || bağlacı yerine && kullandım
 	if user.Role != "admin" && username != blog.Content.Username { // Sadece blogun sahibi veya adminler güncelleme yapabilir
-		return nil, errors.New("you are not authorized to update this blog")
+		return nil, Forbidden("blog_update_forbidden", "you are not authorized to update this blog")
 	}
 
 	if vm == nil {
-		return nil, errors.New("blog is nil")
+		return nil, ErrInvalidInput
 	}
 	existBlog, err := s.br.ExistBlog(ctx, vm.Body)
 	if err != nil {
-		return nil, errors.New("update blog exist error")
+		return nil, fmt.Errorf("update blog exist error: %w", err)
 	}
 	if existBlog {
-		return nil, errors.New("blog with the same body already exists")
+		return nil, ErrDuplicateBody
 	}
 
-	if vm.Title == "" {
-		return nil, errors.New("Blog başlığı boş olamaz")
+	if err := validateSEO(&vm.SEO); err != nil {
+		return nil, err
 	}
 
-	if vm.Body == "" {
-		return nil, errors.New("Blog içeriği boş olamaz")
+	format := vm.BodyFormat
+	if format == "" { // format gönderilmediyse yazının mevcut formatı korunur
+		format = blog.Content.BodyFormat
 	}
-
 	blog.Content = entity.Content{
-		Title:  vm.Title,
-		Body:   vm.Body,
-		Type:   vm.Type,
-		Status: vm.Status,
+		Title:      vm.Title,
+		Body:       vm.Body,
+		BodyFormat: format,
+		Type:       vm.Type,
+		Status:     vm.Status,
+	}
+	if err := render.Apply(&blog.Content); err != nil { // türetilmiş alanlar yeni gövdeden
+		return nil, bodyFormatError(err)
 	}
 	blog.Tags = vm.Tags
 	blog.Category = vm.Category
+	blog.SEO = vm.SEO.ToEntity()
 	blog.BaseModel.UpdatedAt = time.Now()
 
 	resp := &viewmodel.BlogUpdateResponse{
 		Username:  blog.Username,
 		Title:     blog.Title,
 		Body:      blog.Body,
+		BodyHTML:  blog.BodyHTML,
 		Type:      blog.Type,
 		Tags:      blog.Tags,
 		Category:  blog.Category,
 		Status:    blog.Status,
+		SEO:       viewmodel.ToSEOVM(blog.SEO),
 		UpdatedAt: blog.UpdatedAt,
 	}
-	return resp, s.br.Update(ctx, title, blog)
+	if err := s.br.Update(ctx, title, blog); err != nil {
+		return resp, err
+	}
+	s.syncMedia(ctx, blog.ID, blog.Content.Body)
+	return resp, nil
+}
+
+// syncMedia, medya bağı kurulamazsa blog kaydını bozmaz; en kötü ihtimalle GC grace süresi sonrası medya silinir
+func (s *blogService) syncMedia(ctx context.Context, blogID uint, body string) {
+	if err := s.ms.SyncBlog(ctx, blogID, body); err != nil {
+		slog.ErrorContext(ctx, "blog media sync error", "err", err)
+	}
 }
 
 func (s *blogService) DeleteBlog(ctx context.Context, title, username string) (string, error) {
+	ctx, span := tracer.Start(ctx, "BlogService.DeleteBlog")
+	defer span.End()
+
 	if title == "" {
-		return "", errors.New("Invalid Title")
+		return "", ErrInvalidTitle
 	}
 
 	blog, err := s.br.GetBlogByTitle(ctx, title)
 	if err != nil {
-		return "", errors.New("blog not found")
+		return "", blogLookupError(err)
 	}
 	if blog.DeletedAt.Valid {
-		return "", errors.New("blog is already deleted")
+		return "", Conflict("blog_already_deleted", "blog is already deleted")
 	}
 
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return "", errors.New("user not found")
+		return "", ErrUserNotFound
 	}
 
 	if user.Role != "admin" && username != blog.Content.Username {
-		return "", errors.New("you are not authorized to delete this blog")
+		return "", Forbidden("blog_delete_forbidden", "you are not authorized to delete this blog")
 	}
 	return s.br.Delete(ctx, title)
 }
 
 func (s *blogService) GetAllBlogs(ctx context.Context, username string) ([]viewmodel.BlogVM, error) {
+	ctx, span := tracer.Start(ctx, "BlogService.GetAllBlogs")
+	defer span.End()
+
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 	if user.Role != "admin" { // login olan kişi (token sahibi) admin değilse sadece onaylanmış blogları görür
 		blogs, err := s.br.GetAllTrueApproved(ctx)
 		if err != nil {
-			return nil, errors.New("blogs get all true approved error")
+			return nil, fmt.Errorf("blogs get all true approved error: %w", err)
 		}
 		return viewmodel.ToBlogVMs(blogs), nil
 	}
 
 	blogs, err := s.br.GetAll(ctx) // yukarıdaki if'e takılmayan admindir, o yüzden tüm blogları görür
 	if err != nil {
-		return nil, errors.New("blogs get all error")
+		return nil, fmt.Errorf("blogs get all error: %w", err)
 	}
 	return viewmodel.ToBlogVMs(blogs), nil
 }
 
 // Yeni: includeDeleted parametreli versiyon
 func (s *blogService) GetAllBlogsWithOptions(ctx context.Context, username string, includeDeleted bool) ([]viewmodel.BlogVM, error) {
+	ctx, span := tracer.Start(ctx, "BlogService.GetAllBlogsWithOptions")
+	defer span.End()
+
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 
 	// Admin özel durumu
@@ -218,14 +319,14 @@ func (s *blogService) GetAllBlogsWithOptions(ctx context.Context, username strin
 		if includeDeleted {
 			blogs, err := s.br.GetAllIncludeDeleted(ctx)
 			if err != nil {
-				return nil, errors.New("blogs get all include deleted error")
+				return nil, fmt.Errorf("blogs get all include deleted error: %w", err)
 			}
 			return viewmodel.ToBlogVMs(blogs), nil
 		}
 		// admin ama includeDeleted=false
 		blogs, err := s.br.GetAll(ctx)
 		if err != nil {
-			return nil, errors.New("blogs get all error")
+			return nil, fmt.Errorf("blogs get all error: %w", err)
 		}
 		return viewmodel.ToBlogVMs(blogs), nil
 	}
@@ -233,32 +334,35 @@ func (s *blogService) GetAllBlogsWithOptions(ctx context.Context, username strin
 	// Admin değilse: sadece onaylılar
 	blogs, err := s.br.GetAllTrueApproved(ctx)
 	if err != nil {
-		return nil, errors.New("blogs get all true approved error")
+		return nil, fmt.Errorf("blogs get all true approved error: %w", err)
 	}
 	return viewmodel.ToBlogVMs(blogs), nil
 }
 
 func (s *blogService) GetBlogsByAuthor(ctx context.Context, paramUsername, tokenUsername string, includeDeleted bool) ([]viewmodel.BlogVM, error) {
+	ctx, span := tracer.Start(ctx, "BlogService.GetBlogsByAuthor")
+	defer span.End()
+
 	if paramUsername == "" {
-		return nil, errors.New("Invalid Username")
+		return nil, ErrInvalidUser
 	}
 	if tokenUsername == "" {
-		return nil, errors.New("Invalid Token")
+		return nil, ErrInvalidToken
 	}
 	user, err := s.ur.GetByUsername(ctx, tokenUsername)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 
 	// 1) Silinmişleri istiyor mu?
 	if includeDeleted {
 		// Yalnızca sahibi veya admin görebilir
 		if tokenUsername != paramUsername && user.Role != "admin" {
-			return nil, errors.New("not authorized to view deleted blogs of this user")
+			return nil, Forbidden("deleted_blogs_forbidden", "not authorized to view deleted blogs of this user")
 		}
 		blogs, err := s.br.GetBlogsByAuthorIncludeDeleted(ctx, paramUsername)
 		if err != nil {
-			return nil, errors.New("blogs get by author (include deleted) error")
+			return nil, fmt.Errorf("blogs get by author (include deleted) error: %w", err)
 		}
 		return viewmodel.ToBlogVMs(blogs), nil
 	}
@@ -267,28 +371,31 @@ func (s *blogService) GetBlogsByAuthor(ctx context.Context, paramUsername, token
 	if tokenUsername == paramUsername { // eğer login olan kişi (token sahibi) aratılan kullanıcının kendisiyse tüm bloglarını görebilir
 		blogs, err := s.br.GetBlogsByAuthor(ctx, paramUsername)
 		if err != nil {
-			return nil, errors.New("blogs get by author error")
+			return nil, fmt.Errorf("blogs get by author error: %w", err)
 		}
 		return viewmodel.ToBlogVMs(blogs), nil
 	}
 	if user.Role != "admin" { // login olan kişi (token sahibi) admin değilse aratılan kullanıcının sadece onaylanmış bloglarını görür
 		blogs, err := s.br.GetBlogsByAuthorTrueApproved(ctx, paramUsername)
 		if err != nil {
-			return nil, errors.New("blogs get blogs by author true approved error")
+			return nil, fmt.Errorf("blogs get blogs by author true approved error: %w", err)
 		}
 		return viewmodel.ToBlogVMs(blogs), nil
 	}
 
 	blogs, err := s.br.GetBlogsByAuthor(ctx, paramUsername) // yukarıdaki if'e takılmayan admindir, o yüzden aratılan kullanıcının tüm bloglarını görür
 	if err != nil {
-		return nil, errors.New("blog get by author error")
+		return nil, fmt.Errorf("blog get by author error: %w", err)
 	}
 	return viewmodel.ToBlogVMs(blogs), nil
 }
 
 func (s *blogService) GetBlogsByAuthorIncludeDeleted(ctx context.Context, username string) ([]viewmodel.BlogVM, error) {
+	ctx, span := tracer.Start(ctx, "BlogService.GetBlogsByAuthorIncludeDeleted")
+	defer span.End()
+
 	if username == "" {
-		return nil, errors.New("Invalid Username")
+		return nil, ErrInvalidUser
 	}
 
 	blogs, err := s.br.GetBlogsByAuthorIncludeDeleted(ctx, username)
@@ -300,18 +407,21 @@ func (s *blogService) GetBlogsByAuthorIncludeDeleted(ctx context.Context, userna
 }
 
 func (s *blogService) GetBlogByTitle(ctx context.Context, title, username string) (*viewmodel.BlogVM, error) {
+	ctx, span := tracer.Start(ctx, "BlogService.GetBlogByTitle")
+	defer span.End()
+
 	if title == "" {
-		return nil, errors.New("Invalid Title")
+		return nil, ErrInvalidTitle
 	}
 
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 
 	blog, err := s.br.GetBlogByTitle(ctx, title)
 	if err != nil {
-		return nil, errors.New("blog not found")
+		return nil, blogLookupError(err)
 	}
 
 	if blog.Content.Username == username { // login olan kişi (token sahibi) çağırılan blogun yazarıysa onaylanmasa bile görüntülesin
@@ -323,41 +433,96 @@ func (s *blogService) GetBlogByTitle(ctx context.Context, title, username string
 
 	b2, err := s.br.GetBlogByTitleTrueApproved(ctx, title)
 	if err != nil {
-		return nil, errors.New("blog not found or not approved")
+		if repository.IsNotFound(err) {
+			return nil, ErrBlogNotApproved
+		}
+		return nil, err
 	}
 	return viewmodel.ToBlogVM(b2), nil
 }
 
 func (s *blogService) ApproveBlog(ctx context.Context, title, username string, approved bool) error {
+	ctx, span := tracer.Start(ctx, "BlogService.ApproveBlog")
+	defer span.End()
+
 	if title == "" {
-		return errors.New("Invalid Title")
+		return ErrInvalidTitle
 	}
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return errors.New("user not found")
+		return ErrUserNotFound
 	}
 	if user.Role != "admin" {
-		return errors.New("only admin can approve")
+		return Forbidden("admin_only", "only admin can approve")
 	}
 
 	// blog var mı kontrolü
-	if _, err := s.br.GetBlogByTitle(ctx, title); err != nil {
-		return errors.New("blog not found")
+	blog, err := s.br.GetBlogByTitle(ctx, title)
+	if err != nil {
+		return blogLookupError(err)
 	}
-	return s.br.SetApproval(ctx, title, approved)
+	if err := s.br.SetApproval(ctx, title, approved); err != nil {
+		return err
+	}
+
+	key := "blog_approved"
+	if !approved {
+		key = "blog_unapproved"
+	}
+	_ = s.ns.Notify(ctx, blog.Content.Username, "blog.approval", key, map[string]string{"title": blog.Content.Title},
+		"/blog/"+url.PathEscape(blog.Content.Title))
+
+	if approved && !blog.Content.IsApproved { // sadece ilk yayına alınışta
+		metrics.BlogsApproved.Inc()
+		blog.Content.IsApproved = true
+		dispatchWebhook(ctx, s.wh, entity.WebhookEventBlogPublished, blogPublishedPayload(blog))
+	}
+	return nil
 }
 
 func (s *blogService) RestoreBlog(ctx context.Context, title, username string) error {
+	ctx, span := tracer.Start(ctx, "BlogService.RestoreBlog")
+	defer span.End()
+
 	if title == "" {
-		return errors.New("Invalid Title")
+		return ErrInvalidTitle
 	}
 	user, err := s.ur.GetByUsername(ctx, username)
 	if err != nil {
-		return errors.New("user not found")
+		return ErrUserNotFound
 	}
 	if user.Role != "admin" {
-		return errors.New("only admin can restore")
+		return Forbidden("admin_only", "only admin can restore")
 	}
 
-	return s.br.Restore(ctx, title)
+	if err := s.br.Restore(ctx, title); err != nil {
+		return blogLookupError(err)
+	}
+	return nil
+}
+
+const renderBatchSize = 100
+
+func (s *blogService) RenderPending(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "BlogService.RenderPending")
+	defer span.End()
+
+	blogs, err := s.br.ListUnrendered(ctx, renderBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range blogs {
+		content := blogs[i].Content
+		if err := render.Apply(&content); err != nil {
+			// bilinmeyen format: plain olarak işle ki her turda tekrar denenmesin
+			content.BodyFormat = entity.BodyFormatPlain
+			if err := render.Apply(&content); err != nil {
+				return err
+			}
+		}
+		if err := s.br.SaveRendered(ctx, blogs[i].ID, &content); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type BookmarkService interface {
+	CreateBookmark(ctx context.Context, username string, vm *viewmodel.BookmarkCreateVM) (*viewmodel.BookmarkVM, error)
+	UpdateBookmark(ctx context.Context, username string, id uint, vm *viewmodel.BookmarkUpdateVM) (*viewmodel.BookmarkVM, error)
+	DeleteBookmark(ctx context.Context, username string, id uint) error
+	ListBookmarks(ctx context.Context, username string, listID *uint) ([]viewmodel.BookmarkVM, error)
+
+	CreateReadingList(ctx context.Context, username string, vm *viewmodel.ReadingListCreateVM) (*viewmodel.ReadingListVM, error)
+	UpdateReadingList(ctx context.Context, username string, id uint, vm *viewmodel.ReadingListUpdateVM) (*viewmodel.ReadingListVM, error)
+	DeleteReadingList(ctx context.Context, username string, id uint) error
+	ListMyReadingLists(ctx context.Context, username string) ([]viewmodel.ReadingListVM, error)
+	GetReadingList(ctx context.Context, viewerUsername string, id uint) (*viewmodel.ReadingListVM, error)
+	ListPublicReadingLists(ctx context.Context, ownerUsername string) ([]viewmodel.ReadingListVM, error)
+	GetPublicReadingList(ctx context.Context, viewerUsername, ownerUsername, slug string) (*viewmodel.ReadingListVM, error)
+}
+
+type bookmarkService struct {
+	bmr repository.BookmarkRepository
+	br  repository.BlogRepository
+	ur  repository.UserRepository
+}
+
+func NewBookmarkService(bmr repository.BookmarkRepository, br repository.BlogRepository, ur repository.UserRepository) BookmarkService {
+	return &bookmarkService{bmr: bmr, br: br, ur: ur}
+}
+
+var (
+	ErrBookmarkNotFound    = NotFound("bookmark_not_found", "bookmark not found")
+	ErrReadingListNotFound = NotFound("reading_list_not_found", "reading list not found")
+	ErrEmptyListName       = Validation("reading_list_name_required", "Liste adı boş olamaz")
+)
+
+func (s *bookmarkService) CreateBookmark(ctx context.Context, username string, vm *viewmodel.BookmarkCreateVM) (*viewmodel.BookmarkVM, error) {
+	ctx, span := tracer.Start(ctx, "BookmarkService.CreateBookmark")
+	defer span.End()
+
+	if vm == nil || vm.Title == "" {
+		return nil, ErrInvalidTitle
+	}
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	blog, err := s.br.GetBlogByTitle(ctx, vm.Title)
+	if err != nil {
+		return nil, blogLookupError(err)
+	}
+	if !canViewBlog(blog, user) {
+		return nil, ErrBlogNotApproved
+	}
+	if vm.ReadingListID != 0 {
+		if _, err := s.ownedList(ctx, vm.ReadingListID, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	bm := &entity.Bookmark{
+		UserID:        user.ID,
+		ReadingListID: vm.ReadingListID,
+		BlogID:        blog.ID,
+		BlogTitle:     blog.Content.Title,
+		Note:          vm.Note,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	created, err := s.bmr.Create(ctx, bm)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, Conflict("bookmark_exists", "blog is already bookmarked")
+	}
+
+	vms, err := s.toBookmarkVMs(ctx, user, []entity.Bookmark{*bm})
+	if err != nil {
+		return nil, err
+	}
+	return &vms[0], nil
+}
+
+func (s *bookmarkService) UpdateBookmark(ctx context.Context, username string, id uint, vm *viewmodel.BookmarkUpdateVM) (*viewmodel.BookmarkVM, error) {
+	ctx, span := tracer.Start(ctx, "BookmarkService.UpdateBookmark")
+	defer span.End()
+
+	if vm == nil {
+		return nil, ErrInvalidInput
+	}
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	bm, err := s.bmr.GetByID(ctx, id)
+	if err != nil || bm.UserID != user.ID {
+		return nil, ErrBookmarkNotFound
+	}
+
+	if vm.ReadingListID != nil && *vm.ReadingListID != bm.ReadingListID {
+		if *vm.ReadingListID != 0 {
+			if _, err := s.ownedList(ctx, *vm.ReadingListID, user.ID); err != nil {
+				return nil, err
+			}
+		}
+		bm.ReadingListID = *vm.ReadingListID
+	}
+	if vm.Note != nil {
+		bm.Note = *vm.Note
+	}
+	if err := s.bmr.Update(ctx, bm); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return nil, Conflict("bookmark_exists_in_list", "blog is already in this reading list")
+		}
+		return nil, err
+	}
+
+	vms, err := s.toBookmarkVMs(ctx, user, []entity.Bookmark{*bm})
+	if err != nil {
+		return nil, err
+	}
+	return &vms[0], nil
+}
+
+func (s *bookmarkService) DeleteBookmark(ctx context.Context, username string, id uint) error {
+	ctx, span := tracer.Start(ctx, "BookmarkService.DeleteBookmark")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if err := s.bmr.Delete(ctx, id, user.ID); err != nil {
+		return ErrBookmarkNotFound
+	}
+	return nil
+}
+
+func (s *bookmarkService) ListBookmarks(ctx context.Context, username string, listID *uint) ([]viewmodel.BookmarkVM, error) {
+	ctx, span := tracer.Start(ctx, "BookmarkService.ListBookmarks")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	rows, err := s.bmr.ListByUser(ctx, user.ID, listID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toBookmarkVMs(ctx, user, rows)
+}
+
+func (s *bookmarkService) CreateReadingList(ctx context.Context, username string, vm *viewmodel.ReadingListCreateVM) (*viewmodel.ReadingListVM, error) {
+	ctx, span := tracer.Start(ctx, "BookmarkService.CreateReadingList")
+	defer span.End()
+
+	if vm == nil || strings.TrimSpace(vm.Name) == "" {
+		return nil, ErrEmptyListName
+	}
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	slug, err := s.uniqueSlug(ctx, user.ID, vm.Name, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &entity.ReadingList{
+		BaseModel: entity.BaseModel{
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		UserID:      user.ID,
+		Username:    user.Username,
+		Name:        strings.TrimSpace(vm.Name),
+		Slug:        slug,
+		Description: vm.Description,
+		IsPublic:    vm.IsPublic,
+	}
+	if err := s.bmr.CreateList(ctx, l); err != nil {
+		return nil, err
+	}
+	return viewmodel.ToReadingListVM(l), nil
+}
+
+func (s *bookmarkService) UpdateReadingList(ctx context.Context, username string, id uint, vm *viewmodel.ReadingListUpdateVM) (*viewmodel.ReadingListVM, error) {
+	ctx, span := tracer.Start(ctx, "BookmarkService.UpdateReadingList")
+	defer span.End()
+
+	if vm == nil {
+		return nil, ErrInvalidInput
+	}
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	l, err := s.ownedList(ctx, id, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if vm.Name != nil {
+		name := strings.TrimSpace(*vm.Name)
+		if name == "" {
+			return nil, ErrEmptyListName
+		}
+		if name != l.Name {
+			if l.Slug, err = s.uniqueSlug(ctx, user.ID, name, l.ID); err != nil {
+				return nil, err
+			}
+			l.Name = name
+		}
+	}
+	if vm.Description != nil {
+		l.Description = *vm.Description
+	}
+	if vm.IsPublic != nil {
+		l.IsPublic = *vm.IsPublic
+	}
+	l.UpdatedAt = time.Now()
+
+	if err := s.bmr.UpdateList(ctx, l); err != nil {
+		return nil, err
+	}
+	return viewmodel.ToReadingListVM(l), nil
+}
+
+func (s *bookmarkService) DeleteReadingList(ctx context.Context, username string, id uint) error {
+	ctx, span := tracer.Start(ctx, "BookmarkService.DeleteReadingList")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if _, err := s.ownedList(ctx, id, user.ID); err != nil {
+		return err
+	}
+	return s.bmr.DeleteList(ctx, id)
+}
+
+func (s *bookmarkService) ListMyReadingLists(ctx context.Context, username string) ([]viewmodel.ReadingListVM, error) {
+	ctx, span := tracer.Start(ctx, "BookmarkService.ListMyReadingLists")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	rows, err := s.bmr.ListListsByUser(ctx, user.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	return viewmodel.ToReadingListVMs(rows), nil
+}
+
+// GetReadingList: sahibi her zaman, diğer kullanıcılar sadece public listeleri görebilir
+func (s *bookmarkService) GetReadingList(ctx context.Context, viewerUsername string, id uint) (*viewmodel.ReadingListVM, error) {
+	ctx, span := tracer.Start(ctx, "BookmarkService.GetReadingList")
+	defer span.End()
+
+	viewer, err := s.ur.GetByUsername(ctx, viewerUsername)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	l, err := s.bmr.GetListByID(ctx, id)
+	if err != nil || (l.UserID != viewer.ID && !l.IsPublic) {
+		return nil, ErrReadingListNotFound
+	}
+	return s.withItems(ctx, viewer, l)
+}
+
+func (s *bookmarkService) ListPublicReadingLists(ctx context.Context, ownerUsername string) ([]viewmodel.ReadingListVM, error) {
+	ctx, span := tracer.Start(ctx, "BookmarkService.ListPublicReadingLists")
+	defer span.End()
+
+	owner, err := s.ur.GetByUsername(ctx, ownerUsername)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	rows, err := s.bmr.ListListsByUser(ctx, owner.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	return viewmodel.ToReadingListVMs(rows), nil
+}
+
+func (s *bookmarkService) GetPublicReadingList(ctx context.Context, viewerUsername, ownerUsername, slug string) (*viewmodel.ReadingListVM, error) {
+	ctx, span := tracer.Start(ctx, "BookmarkService.GetPublicReadingList")
+	defer span.End()
+
+	viewer, err := s.ur.GetByUsername(ctx, viewerUsername)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	owner, err := s.ur.GetByUsername(ctx, ownerUsername)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	l, err := s.bmr.GetListBySlug(ctx, owner.ID, slug)
+	if err != nil || (l.UserID != viewer.ID && !l.IsPublic) {
+		return nil, ErrReadingListNotFound
+	}
+	return s.withItems(ctx, viewer, l)
+}
+
+func (s *bookmarkService) withItems(ctx context.Context, viewer *entity.User, l *entity.ReadingList) (*viewmodel.ReadingListVM, error) {
+	rows, err := s.bmr.ListByReadingList(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+	vm := viewmodel.ToReadingListVM(l)
+	if vm.Items, err = s.toBookmarkVMs(ctx, viewer, rows); err != nil {
+		return nil, err
+	}
+	return vm, nil
+}
+
+func (s *bookmarkService) ownedList(ctx context.Context, id, userID uint) (*entity.ReadingList, error) {
+	l, err := s.bmr.GetListByID(ctx, id)
+	if err != nil || l.UserID != userID {
+		return nil, ErrReadingListNotFound
+	}
+	return l, nil
+}
+
+// uniqueSlug, kullanıcının listeleri arasında çakışma olursa sonuna sayı ekler
+func (s *bookmarkService) uniqueSlug(ctx context.Context, userID uint, name string, excludeID uint) (string, error) {
+	base := slugify(name)
+	if base == "" {
+		base = "list"
+	}
+	slug := base
+	for i := 2; ; i++ {
+		exist, err := s.bmr.ExistListSlug(ctx, userID, slug, excludeID)
+		if err != nil {
+			return "", err
+		}
+		if !exist {
+			return slug, nil
+		}
+		slug = base + "-" + strconv.Itoa(i)
+	}
+}
+
+// toBookmarkVMs, blogları silinmişler dahil tek sorguda çeker. Silinmiş veya onayı kaldırılmış
+// bloglar listeden kaybolmaz, "unavailable" olarak işaretlenir; başlık ve içerik sadece
+// görüntülenebilen bloglar için döner.
+func (s *bookmarkService) toBookmarkVMs(ctx context.Context, viewer *entity.User, rows []entity.Bookmark) ([]viewmodel.BookmarkVM, error) {
+	ids := make([]uint, 0, len(rows))
+	for _, b := range rows {
+		ids = append(ids, b.BlogID)
+	}
+	found, err := s.br.GetByIDsIncludeDeleted(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("bookmark blogs error: %w", err)
+	}
+	blogs := make(map[uint]*entity.Blog, len(found))
+	for i := range found {
+		blogs[found[i].ID] = &found[i]
+	}
+
+	out := make([]viewmodel.BookmarkVM, len(rows))
+	for i, b := range rows {
+		vm := viewmodel.BookmarkVM{
+			ID:            b.ID,
+			BlogID:        b.BlogID,
+			ReadingListID: b.ReadingListID,
+			Note:          b.Note,
+			CreatedAt:     b.CreatedAt,
+		}
+		blog, ok := blogs[b.BlogID]
+		switch {
+		case !ok || blog.DeletedAt.Valid:
+			vm.Title = b.BlogTitle
+			vm.UnavailableReason = "deleted"
+		case !canViewBlog(blog, viewer):
+			vm.Title = b.BlogTitle
+			vm.UnavailableReason = "unapproved"
+		default:
+			vm.Available = true
+			vm.Title = blog.Content.Title
+			vm.Blog = viewmodel.ToBlogVM(blog)
+		}
+		out[i] = vm
+	}
+	return out, nil
+}
+
+// canViewBlog: onaylı bloglar herkese, onaysızlar sadece yazarına ve adminlere açık
+func canViewBlog(b *entity.Blog, u *entity.User) bool {
+	return b.Content.IsApproved || b.Content.Username == u.Username || u.Role == entity.RoleAdmin
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+	"errors"
+	"strings"
+)
+
+// ErrorKind, domain hatasının türü; HTTP katmanı status kodunu buna göre seçer
+type ErrorKind string
+
+const (
+	KindValidation   ErrorKind = "validation"
+	KindUnauthorized ErrorKind = "unauthorized"
+	KindForbidden    ErrorKind = "forbidden"
+	KindNotFound     ErrorKind = "not_found"
+	KindConflict     ErrorKind = "conflict"
+)
+
+// Error, servislerin döndüğü tipli hata. Code client'ların dayanabileceği sabit bir
+// anahtardır (ör. "blog_not_found"), Message ise insan için açıklama.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+	Err     error             // varsa alttaki hata, cevaba yazılmaz
+	Fields  []FieldError      // validation hatalarında alan bazlı detaylar
+	Params  map[string]string // mesaj kataloğundaki {isim} yer tutucuları için
+}
+
+// FieldError, tek bir alanın validation hatası. Field JSON adıdır, Rule ihlal edilen kural.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+	Key     string `json:"-"` // katalog anahtarı: "validation.required", "validation.min_items" ...
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is, aynı Code'a sahip hataları eşit sayar; böylece Wrap'lenmiş sentinel'ler de errors.Is ile yakalanır
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap, hatanın alttaki sebebi taşıyan kopyasını döner (sentinel'ler değişmesin diye)
+func (e *Error) Wrap(err error) *Error {
+	cp := *e
+	cp.Err = err
+	return &cp
+}
+
+// With, mesaj parametresi eklenmiş kopyayı döner: FileTooLarge.With("max", "10485760")
+func (e *Error) With(key, value string) *Error {
+	cp := *e
+	cp.Params = make(map[string]string, len(e.Params)+1)
+	for k, v := range e.Params {
+		cp.Params[k] = v
+	}
+	cp.Params[key] = value
+	return &cp
+}
+
+// Localize, hatanın locale'deki mesajı; alan hataları "alan: mesaj" olarak eklenir. HTTP cevabı
+// dışında kullanıcıya gösterilen hatalar (import raporu vb.) için; tipsiz hatalarda err.Error() döner.
+func Localize(locale string, err error) string {
+	var e *Error
+	if !errors.As(err, &e) {
+		return err.Error()
+	}
+	msg := i18n.T(locale, "errors."+e.Code, e.Message, e.Params)
+	if len(e.Fields) == 0 {
+		return msg
+	}
+	fields := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		fields[i] = f.Field + ": " + i18n.T(locale, f.Key, f.Message, map[string]string{"param": f.Param})
+	}
+	return msg + " (" + strings.Join(fields, "; ") + ")"
+}
+
+func newError(kind ErrorKind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func Validation(code, message string) *Error   { return newError(KindValidation, code, message) }
+func Unauthorized(code, message string) *Error { return newError(KindUnauthorized, code, message) }
+func Forbidden(code, message string) *Error    { return newError(KindForbidden, code, message) }
+func NotFound(code, message string) *Error     { return newError(KindNotFound, code, message) }
+func Conflict(code, message string) *Error     { return newError(KindConflict, code, message) }
+
+// Birden fazla serviste ve handler'da kullanılan hatalar
+var (
+	ErrInvalidInput = Validation("invalid_input", "invalid input")
+	ErrInvalidToken = Unauthorized("invalid_token", "Invalid token username")
+	ErrNotAllowed   = Forbidden("not_allowed", "not allowed")
+	ErrUserNotFound = NotFound("user_not_found", "user not found")
+	ErrUserDeleted  = Forbidden("user_deleted", "user is deleted")
+	ErrBlogNotFound = NotFound("blog_not_found", "blog not found")
+	ErrInvalidTitle = Validation("invalid_title", "Invalid Title")
+	ErrInvalidUser  = Validation("invalid_username", "Invalid username")
+	ErrInvalidID    = Validation("invalid_id", "invalid id")
+)
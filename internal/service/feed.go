@@ -0,0 +1,151 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const feedSummaryLen = 300
+
+// ErrFeedNotFound, yazar yoksa döner (handler 404'e çevirir)
+var ErrFeedNotFound = NotFound("feed_not_found", "feed not found")
+
+type FeedService interface {
+	SiteFeed(ctx context.Context) (*viewmodel.FeedVM, error)
+	// locale, feed açıklamasının dili
+	AuthorFeed(ctx context.Context, locale, username string) (*viewmodel.FeedVM, error)
+	TagFeed(ctx context.Context, locale, tag string) (*viewmodel.FeedVM, error)
+	CategoryFeed(ctx context.Context, locale, category string) (*viewmodel.FeedVM, error)
+}
+
+type feedService struct {
+	br  repository.BlogRepository
+	ur  repository.UserRepository
+	cfg config.SiteConfig
+}
+
+func NewFeedService(br repository.BlogRepository, ur repository.UserRepository, cfg config.SiteConfig) FeedService {
+	return &feedService{br: br, ur: ur, cfg: cfg}
+}
+
+func (s *feedService) SiteFeed(ctx context.Context) (*viewmodel.FeedVM, error) {
+	ctx, span := tracer.Start(ctx, "FeedService.SiteFeed")
+	defer span.End()
+
+	return s.build(ctx, repository.PublishedFilter{}, s.cfg.Title, s.cfg.Description, "/", "/feeds")
+}
+
+func (s *feedService) AuthorFeed(ctx context.Context, locale, username string) (*viewmodel.FeedVM, error) {
+	ctx, span := tracer.Start(ctx, "FeedService.AuthorFeed")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil || user == nil {
+		return nil, ErrFeedNotFound
+	}
+	return s.build(ctx, repository.PublishedFilter{Username: user.Username},
+		fmt.Sprintf("%s - %s", s.cfg.Title, user.Username),
+		i18n.T(locale, "feeds.author_description", "Posts by "+user.Username, map[string]string{"username": user.Username}),
+		"/u/"+url.PathEscape(user.Username), "/feeds/user/"+url.PathEscape(user.Username))
+}
+
+func (s *feedService) TagFeed(ctx context.Context, locale, tag string) (*viewmodel.FeedVM, error) {
+	ctx, span := tracer.Start(ctx, "FeedService.TagFeed")
+	defer span.End()
+
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil, Validation("tag_required", "tag required")
+	}
+	return s.build(ctx, repository.PublishedFilter{Tag: tag},
+		fmt.Sprintf("%s - #%s", s.cfg.Title, tag),
+		i18n.T(locale, "feeds.tag_description", "Posts tagged "+tag, map[string]string{"tag": tag}),
+		"/blogs?tag="+url.QueryEscape(tag), "/feeds/tag/"+url.PathEscape(tag))
+}
+
+func (s *feedService) CategoryFeed(ctx context.Context, locale, category string) (*viewmodel.FeedVM, error) {
+	ctx, span := tracer.Start(ctx, "FeedService.CategoryFeed")
+	defer span.End()
+
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return nil, Validation("category_required", "category required")
+	}
+	return s.build(ctx, repository.PublishedFilter{Category: category},
+		fmt.Sprintf("%s - %s", s.cfg.Title, category),
+		i18n.T(locale, "feeds.category_description", "Posts in "+category, map[string]string{"category": category}),
+		"/blogs?category="+url.QueryEscape(category), "/feeds/category/"+url.PathEscape(category))
+}
+
+func (s *feedService) build(ctx context.Context, filter repository.PublishedFilter, title, description, pagePath, selfPath string) (*viewmodel.FeedVM, error) {
+	blogs, err := s.br.ListPublished(ctx, filter, s.cfg.FeedLimit)
+	if err != nil {
+		return nil, fmt.Errorf("feed get error: %w", err)
+	}
+	// yazar adı ve etiket değişince yazı filtreden çıkabildiği için site geneli alınır
+	changed, err := s.br.LastChange(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("feed get error: %w", err)
+	}
+
+	base := strings.TrimRight(s.cfg.BaseURL, "/")
+	feed := &viewmodel.FeedVM{
+		Title:       title,
+		Description: description,
+		Link:        base + pagePath,
+		SelfLink:    base + selfPath,
+		Changed:     changed,
+		Items:       make([]viewmodel.FeedItemVM, 0, len(blogs)),
+	}
+	for i := range blogs {
+		b := &blogs[i]
+		if b.UpdatedAt.After(feed.Updated) {
+			feed.Updated = b.UpdatedAt
+		}
+		feed.Items = append(feed.Items, viewmodel.FeedItemVM{
+			Title:      b.Title,
+			Link:       blogURL(base, b.Title),
+			GUID:       fmt.Sprintf("%s/blog/%d", base, b.ID),
+			Author:     b.Username,
+			Summary:    feedSummary(b),
+			Content:    b.BodyHTML,
+			Categories: feedCategories(b),
+			Published:  b.CreatedAt,
+			Updated:    b.UpdatedAt,
+		})
+	}
+	return feed, nil
+}
+
+func feedSummary(b *entity.Blog) string {
+	if b.Excerpt != "" {
+		return b.Excerpt
+	}
+	body := strings.Join(strings.Fields(b.Body), " ")
+	if utf8.RuneCountInString(body) <= feedSummaryLen {
+		return body
+	}
+	r := []rune(body)
+	return strings.TrimSpace(string(r[:feedSummaryLen])) + "…"
+}
+
+func feedCategories(b *entity.Blog) []string {
+	var out []string
+	if b.Category != "" {
+		out = append(out, b.Category)
+	}
+	for _, t := range strings.Split(b.Tags, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bytes"
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/infrastructure/imaging"
+	"cleanArch_with_postgres/internal/infrastructure/storage"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const mediaGCBatchSize = 100
+
+type MediaService interface {
+	Upload(ctx context.Context, username, filename string, data []byte) (*viewmodel.MediaVM, error)
+	List(ctx context.Context, username string) (*viewmodel.MediaListVM, error)
+	Delete(ctx context.Context, username string, id uint) error
+	// SyncBlog, blog gövdesinde geçen medya adreslerini bloga bağlar
+	SyncBlog(ctx context.Context, blogID uint, body string) error
+	// CollectGarbage, hiçbir blogun referans vermediği eski medyayı siler
+	CollectGarbage(ctx context.Context) error
+}
+
+type mediaService struct {
+	mr    repository.MediaRepository
+	ur    repository.UserRepository
+	store storage.Storage
+	cfg   config.MediaConfig
+	urlRe *regexp.Regexp
+}
+
+func NewMediaService(mr repository.MediaRepository, ur repository.UserRepository, store storage.Storage, cfg config.MediaConfig) MediaService {
+	return &mediaService{
+		mr:    mr,
+		ur:    ur,
+		store: store,
+		cfg:   cfg,
+		urlRe: regexp.MustCompile(regexp.QuoteMeta(store.URL("")) + `([A-Za-z0-9/_.\-]+)`),
+	}
+}
+
+func (s *mediaService) Upload(ctx context.Context, username, filename string, data []byte) (*viewmodel.MediaVM, error) {
+	ctx, span := tracer.Start(ctx, "MediaService.Upload")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Role != "admin" && user.Role != "writer" {
+		return nil, Forbidden("media_upload_forbidden", "User is not authorized to upload media")
+	}
+	if len(data) == 0 {
+		return nil, Validation("file_empty", "file is empty")
+	}
+	if int64(len(data)) > s.cfg.MaxSize {
+		return nil, Validation("file_too_large", fmt.Sprintf("file is too large, max %d bytes", s.cfg.MaxSize)).
+			With("max", strconv.FormatInt(s.cfg.MaxSize, 10))
+	}
+
+	contentType, err := imaging.Sniff(data)
+	if err != nil {
+		return nil, s.imageError(err)
+	}
+	img, err := imaging.Process(data, s.cfg.ThumbWidth, s.cfg.MaxPixels)
+	if err != nil {
+		return nil, s.imageError(err)
+	}
+
+	name, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf("u/%d/%s/%s", user.ID, time.Now().Format("2006/01"), name)
+	key := prefix + imaging.Extensions[contentType]
+	thumbKey := prefix + "_thumb" + imaging.Extensions[img.ThumbType]
+
+	if err := s.store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		slog.ErrorContext(ctx, "media put error", "key", key, "err", err)
+		return nil, fmt.Errorf("media upload error: %w", err)
+	}
+	if err := s.store.Put(ctx, thumbKey, bytes.NewReader(img.Thumb), int64(len(img.Thumb)), img.ThumbType); err != nil {
+		slog.ErrorContext(ctx, "media put error", "key", thumbKey, "err", err)
+		_ = s.store.Delete(ctx, key)
+		return nil, fmt.Errorf("media upload error: %w", err)
+	}
+
+	m := &entity.Media{
+		UserID:       user.ID,
+		Username:     user.Username,
+		Key:          key,
+		ThumbKey:     thumbKey,
+		OriginalName: filepath.Base(filename),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		Width:        img.Width,
+		Height:       img.Height,
+		CreatedAt:    time.Now(),
+	}
+	// kota kaydın eklendiği transaction'da kontrol edilir, eşzamanlı yüklemeler birlikte aşamaz
+	if used, err := s.mr.CreateWithinQuota(ctx, m, s.cfg.UserQuota); err != nil {
+		_ = s.store.Delete(ctx, key)
+		_ = s.store.Delete(ctx, thumbKey)
+		if errors.Is(err, repository.ErrQuotaExceeded) {
+			return nil, Conflict("media_quota_exceeded", fmt.Sprintf("media quota exceeded (%d / %d bytes used)", used, s.cfg.UserQuota)).
+				With("used", strconv.FormatInt(used, 10)).With("quota", strconv.FormatInt(s.cfg.UserQuota, 10))
+		}
+		return nil, fmt.Errorf("media create error: %w", err)
+	}
+
+	vm := viewmodel.ToMediaVM(m, s.store.URL)
+	return &vm, nil
+}
+
+// imageError, imaging hatalarını istemciye dönen validation hatalarına çevirir
+func (s *mediaService) imageError(err error) error {
+	switch {
+	case errors.Is(err, imaging.ErrUnsupported):
+		return Validation("unsupported_image", err.Error())
+	case errors.Is(err, imaging.ErrTooManyPixels):
+		return Validation("image_too_large", fmt.Sprintf("image is too large, max %d pixels", s.cfg.MaxPixels)).
+			With("max", strconv.Itoa(s.cfg.MaxPixels))
+	}
+	return err
+}
+
+func (s *mediaService) List(ctx context.Context, username string) (*viewmodel.MediaListVM, error) {
+	ctx, span := tracer.Start(ctx, "MediaService.List")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	list, err := s.mr.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("media list error: %w", err)
+	}
+	out := &viewmodel.MediaListVM{Items: make([]viewmodel.MediaVM, len(list)), Quota: s.cfg.UserQuota}
+	for i := range list {
+		out.Items[i] = viewmodel.ToMediaVM(&list[i], s.store.URL)
+		out.Used += list[i].Size
+	}
+	return out, nil
+}
+
+// Delete, bir blogda kullanılan medyanın silinmesine izin vermez; önce gövdeden çıkarılmalı
+func (s *mediaService) Delete(ctx context.Context, username string, id uint) error {
+	ctx, span := tracer.Start(ctx, "MediaService.Delete")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	m, err := s.mr.GetByID(ctx, id)
+	if err != nil {
+		return NotFound("media_not_found", "media not found")
+	}
+	if user.Role != "admin" && m.UserID != user.ID {
+		return Forbidden("media_delete_forbidden", "you are not authorized to delete this media")
+	}
+	refs, err := s.mr.CountBlogRefs(ctx, m.ID)
+	if err != nil {
+		return fmt.Errorf("media delete error: %w", err)
+	}
+	if refs > 0 {
+		return Conflict("media_in_use", "media is used by a blog")
+	}
+	return s.remove(ctx, m)
+}
+
+func (s *mediaService) SyncBlog(ctx context.Context, blogID uint, body string) error {
+	ctx, span := tracer.Start(ctx, "MediaService.SyncBlog")
+	defer span.End()
+
+	seen := map[string]bool{}
+	var keys []string
+	for _, match := range s.urlRe.FindAllStringSubmatch(body, -1) {
+		if key := strings.TrimRight(match[1], "."); !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	list, err := s.mr.GetByKeys(ctx, keys)
+	if err != nil {
+		return err
+	}
+	ids := make([]uint, len(list))
+	for i := range list {
+		ids[i] = list[i].ID
+	}
+	return s.mr.SetBlogMedia(ctx, blogID, ids)
+}
+
+func (s *mediaService) CollectGarbage(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "MediaService.CollectGarbage")
+	defer span.End()
+
+	orphans, err := s.mr.ListOrphans(ctx, time.Now().Add(-s.cfg.GCGrace), mediaGCBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range orphans {
+		if err := s.remove(ctx, &orphans[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remove, önce dosyaları siler; satır kalırsa bir sonraki GC turu tekrar dener
+func (s *mediaService) remove(ctx context.Context, m *entity.Media) error {
+	for _, key := range []string{m.Key, m.ThumbKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.store.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			slog.ErrorContext(ctx, "media delete error", "key", key, "err", err)
+			return fmt.Errorf("media delete error: %w", err)
+		}
+	}
+	return s.mr.Delete(ctx, m.ID)
+}
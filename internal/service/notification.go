@@ -0,0 +1,122 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/eventbus"
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type NotificationService interface {
+	// Notify: mesaj, "notifications.<key>" katalog anahtarının alıcının dilindeki karşılığıdır
+	Notify(ctx context.Context, username, notifType, key string, params map[string]string, link string) error
+	NotifyAdmins(ctx context.Context, eventType string, data interface{}) error
+	List(ctx context.Context, username string, unreadOnly bool, limit int) ([]viewmodel.NotificationVM, error)
+	MarkRead(ctx context.Context, id uint, username string) error
+	MarkAllRead(ctx context.Context, username string) error
+}
+
+type notificationService struct {
+	nr  repository.NotificationRepository
+	ur  repository.UserRepository
+	bus eventbus.Bus
+}
+
+func NewNotificationService(nr repository.NotificationRepository, ur repository.UserRepository, bus eventbus.Bus) NotificationService {
+	return &notificationService{nr: nr, ur: ur, bus: bus}
+}
+
+// Notify, bildirimi alıcının dilinde (dil seçmemişse varsayılan dilde) kaydeder ve bağlı olan
+// kullanıcıya anlık olarak iletir. Mesaj kayıt anında çevrilir, sonradan dil değişse de aynı kalır.
+func (s *notificationService) Notify(ctx context.Context, username, notifType, key string, params map[string]string, link string) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.Notify")
+	defer span.End()
+
+	if username == "" {
+		return ErrInvalidUser
+	}
+	locale := i18n.Default()
+	if u, err := s.ur.GetByUsername(ctx, username); err == nil && u.Locale != "" {
+		locale = u.Locale
+	}
+	n := &entity.Notification{
+		BaseModel: entity.BaseModel{
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		Username: username,
+		Type:     notifType,
+		Message:  i18n.T(locale, "notifications."+key, key, params),
+		Link:     link,
+	}
+	if err := s.nr.Create(ctx, n); err != nil {
+		return err
+	}
+
+	e, err := eventbus.NewEvent(eventbus.NotificationCreated, viewmodel.ToNotificationVM(n))
+	if err != nil {
+		return err
+	}
+	e.Recipient = username
+	s.publish(ctx, e)
+	return nil
+}
+
+// NotifyAdmins, kalıcı kayıt oluşturmadan sadece admin paneline event yollar
+func (s *notificationService) NotifyAdmins(ctx context.Context, eventType string, data interface{}) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.NotifyAdmins")
+	defer span.End()
+
+	e, err := eventbus.NewEvent(eventType, data)
+	if err != nil {
+		return err
+	}
+	e.AdminsOnly = true
+	s.publish(ctx, e)
+	return nil
+}
+
+// publish hatası asıl işlemi bozmasın, sadece loglanır
+func (s *notificationService) publish(ctx context.Context, e eventbus.Event) {
+	if err := s.bus.Publish(ctx, e); err != nil {
+		slog.ErrorContext(ctx, "event publish error", "err", err)
+	}
+}
+
+func (s *notificationService) List(ctx context.Context, username string, unreadOnly bool, limit int) ([]viewmodel.NotificationVM, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.List")
+	defer span.End()
+
+	if username == "" {
+		return nil, ErrInvalidUser
+	}
+	rows, err := s.nr.ListByUser(ctx, username, unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	return viewmodel.ToNotificationVMs(rows), nil
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, id uint, username string) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.MarkRead")
+	defer span.End()
+
+	if id == 0 {
+		return ErrInvalidID
+	}
+	if err := s.nr.MarkRead(ctx, id, username); err != nil {
+		return NotFound("notification_not_found", "notification not found")
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, username string) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.MarkAllRead")
+	defer span.End()
+
+	return s.nr.MarkAllRead(ctx, username)
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// erasureBatchSize, worker'ın tek turda işlediği talep sayısı
+const erasureBatchSize = 20
+
+var (
+	ErrNoPendingErasure      = NotFound("erasure_not_found", "no pending erasure request")
+	ErrErasureAlreadyPending = Conflict("erasure_pending", "erasure already requested")
+)
+
+type PrivacyService interface {
+	// ExportData, kullanıcının profilini, yazılarını, yorumlarını, tepkilerini ve rol taleplerini döner
+	ExportData(ctx context.Context, username string) (*viewmodel.PersonalDataVM, error)
+	RequestErasure(ctx context.Context, username, password string) (*viewmodel.ErasureRequestVM, error)
+	GetErasure(ctx context.Context, username string) (*viewmodel.ErasureRequestVM, error)
+	CancelErasure(ctx context.Context, username string) error
+	// ProcessDueErasures, bekleme süresi dolan talepleri uygular (worker)
+	ProcessDueErasures(ctx context.Context) error
+}
+
+type privacyService struct {
+	pr  repository.PrivacyRepository
+	ur  repository.UserRepository
+	br  repository.BlogRepository
+	ns  NotificationService
+	cfg config.PrivacyConfig
+}
+
+func NewPrivacyService(pr repository.PrivacyRepository, ur repository.UserRepository, br repository.BlogRepository, ns NotificationService, cfg config.PrivacyConfig) PrivacyService {
+	return &privacyService{pr: pr, ur: ur, br: br, ns: ns, cfg: cfg}
+}
+
+func (s *privacyService) ExportData(ctx context.Context, username string) (*viewmodel.PersonalDataVM, error) {
+	ctx, span := tracer.Start(ctx, "PrivacyService.ExportData")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	blogs, err := s.br.GetBlogsByAuthorIncludeDeleted(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.pr.ListComments(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	reactions, err := s.pr.ListReactions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	roleRequests, err := s.pr.ListRoleRequests(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.pr.PendingErasure(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &viewmodel.PersonalDataVM{
+		ExportedAt:   time.Now().UTC(),
+		Profile:      *viewmodel.ToUserVM(user),
+		Posts:        viewmodel.ToBlogVMs(blogs),
+		Comments:     viewmodel.ToCommentVMs(comments),
+		Reactions:    make([]viewmodel.UserReactionVM, 0, len(reactions)),
+		RoleRequests: viewmodel.ToRoleReqVMs(roleRequests),
+	}
+	for _, r := range reactions {
+		out.Reactions = append(out.Reactions, viewmodel.UserReactionVM{
+			BlogID:    r.BlogID,
+			BlogTitle: r.BlogTitle,
+			Type:      r.Type,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	if pending != nil {
+		out.Erasure = viewmodel.ToErasureRequestVM(pending)
+	}
+	return out, nil
+}
+
+// RequestErasure, hesabı ErasureGrace sonrasına anonimleştirilmek üzere planlar.
+// Yanlışlıkla ya da çalınan token ile silinmeye karşı şifre tekrar istenir.
+func (s *privacyService) RequestErasure(ctx context.Context, username, password string) (*viewmodel.ErasureRequestVM, error) {
+	ctx, span := tracer.Start(ctx, "PrivacyService.RequestErasure")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		return nil, Unauthorized("invalid_password", "invalid password")
+	}
+
+	pending, err := s.pr.PendingErasure(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, ErrErasureAlreadyPending
+	}
+
+	now := time.Now()
+	req := &entity.ErasureRequest{
+		UserID:      user.ID,
+		Status:      entity.ErasurePending,
+		ScheduledAt: now.Add(s.cfg.ErasureGrace),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	details, _ := json.Marshal(map[string]interface{}{"scheduled_at": req.ScheduledAt})
+	audit := &entity.AuditLog{
+		Actor:      username,
+		Action:     entity.AuditErasureRequested,
+		TargetType: "user",
+		Details:    string(details),
+		CreatedAt:  now,
+	}
+	if err := s.pr.CreateErasure(ctx, req, audit); err != nil {
+		return nil, err
+	}
+
+	_ = s.ns.Notify(ctx, username, "account.erasure_scheduled", "erasure_scheduled",
+		map[string]string{"date": req.ScheduledAt.Format("2006-01-02 15:04 MST")}, "/me")
+	return viewmodel.ToErasureRequestVM(req), nil
+}
+
+func (s *privacyService) GetErasure(ctx context.Context, username string) (*viewmodel.ErasureRequestVM, error) {
+	ctx, span := tracer.Start(ctx, "PrivacyService.GetErasure")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	pending, err := s.pr.PendingErasure(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil {
+		return nil, ErrNoPendingErasure
+	}
+	return viewmodel.ToErasureRequestVM(pending), nil
+}
+
+func (s *privacyService) CancelErasure(ctx context.Context, username string) error {
+	ctx, span := tracer.Start(ctx, "PrivacyService.CancelErasure")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	pending, err := s.pr.PendingErasure(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if pending == nil {
+		return ErrNoPendingErasure
+	}
+
+	audit := &entity.AuditLog{
+		Actor:      username,
+		Action:     entity.AuditErasureCancelled,
+		TargetType: "user",
+		TargetID:   user.ID,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.pr.CancelErasure(ctx, pending, audit); err != nil {
+		if errors.Is(err, repository.ErrErasureNotPending) {
+			return ErrNoPendingErasure
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *privacyService) ProcessDueErasures(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "PrivacyService.ProcessDueErasures")
+	defer span.End()
+
+	due, err := s.pr.ListDueErasures(ctx, time.Now(), erasureBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range due {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// biri hata verirse diğerleri bekletilmez, sonraki turda tekrar denenir (hata repository'de loglanır)
+		_, _ = s.pr.Erase(ctx, &due[i], s.cfg.ErasurePolicy, s.cfg.PlaceholderUser)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"fmt"
+	"strings"
+)
+
+type ReactionService interface {
+	React(ctx context.Context, title, username, reactionType string) (*viewmodel.ReactionSummaryVM, error)
+	Unreact(ctx context.Context, title, username, reactionType string) (*viewmodel.ReactionSummaryVM, error)
+	ListLiked(ctx context.Context, username string, limit int) ([]viewmodel.BlogVM, error)
+	Types() []string
+}
+
+type reactionService struct {
+	rr    repository.ReactionRepository
+	br    repository.BlogRepository
+	ur    repository.UserRepository
+	types []string
+}
+
+func NewReactionService(rr repository.ReactionRepository, br repository.BlogRepository, ur repository.UserRepository, cfg config.ReactionConfig) ReactionService {
+	// like her zaman ilk sırada ve geçerli
+	types := []string{entity.ReactionLike}
+	for _, t := range cfg.Types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || containsString(types, t) {
+			continue
+		}
+		types = append(types, t)
+	}
+	return &reactionService{rr: rr, br: br, ur: ur, types: types}
+}
+
+func (s *reactionService) Types() []string {
+	return s.types
+}
+
+func (s *reactionService) React(ctx context.Context, title, username, reactionType string) (*viewmodel.ReactionSummaryVM, error) {
+	ctx, span := tracer.Start(ctx, "ReactionService.React")
+	defer span.End()
+
+	blog, user, reactionType, err := s.prepare(ctx, title, username, reactionType)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.rr.Add(ctx, blog.ID, user.ID, reactionType); err != nil {
+		return nil, fmt.Errorf("reaction add error: %w", err)
+	}
+	return s.summary(ctx, title, user.ID)
+}
+
+func (s *reactionService) Unreact(ctx context.Context, title, username, reactionType string) (*viewmodel.ReactionSummaryVM, error) {
+	ctx, span := tracer.Start(ctx, "ReactionService.Unreact")
+	defer span.End()
+
+	blog, user, reactionType, err := s.prepare(ctx, title, username, reactionType)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.rr.Remove(ctx, blog.ID, user.ID, reactionType); err != nil {
+		return nil, fmt.Errorf("reaction remove error: %w", err)
+	}
+	return s.summary(ctx, title, user.ID)
+}
+
+func (s *reactionService) ListLiked(ctx context.Context, username string, limit int) ([]viewmodel.BlogVM, error) {
+	ctx, span := tracer.Start(ctx, "ReactionService.ListLiked")
+	defer span.End()
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	blogs, err := s.rr.ListBlogsByUserReaction(ctx, user.ID, entity.ReactionLike, limit)
+	if err != nil {
+		return nil, fmt.Errorf("liked blogs get error: %w", err)
+	}
+	return viewmodel.ToBlogVMs(blogs), nil
+}
+
+// prepare, tepki tipini doğrular ve kullanıcının blogu görebildiğinden emin olur
+// (onaysız bloglara sadece yazarı ve adminler tepki verebilir)
+func (s *reactionService) prepare(ctx context.Context, title, username, reactionType string) (*entity.Blog, *entity.User, string, error) {
+	if title == "" {
+		return nil, nil, "", ErrInvalidTitle
+	}
+	reactionType = strings.ToLower(strings.TrimSpace(reactionType))
+	if reactionType == "" {
+		reactionType = entity.ReactionLike
+	}
+	if !containsString(s.types, reactionType) {
+		return nil, nil, "", Validation("invalid_reaction_type", "invalid reaction type")
+	}
+
+	user, err := s.ur.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, nil, "", ErrUserNotFound
+	}
+	blog, err := s.br.GetBlogByTitle(ctx, title)
+	if err != nil {
+		return nil, nil, "", ErrBlogNotFound
+	}
+	if !canViewBlog(blog, user) {
+		return nil, nil, "", ErrBlogNotApproved
+	}
+	return blog, user, reactionType, nil
+}
+
+func (s *reactionService) summary(ctx context.Context, title string, userID uint) (*viewmodel.ReactionSummaryVM, error) {
+	blog, err := s.br.GetBlogByTitle(ctx, title)
+	if err != nil {
+		return nil, ErrBlogNotFound
+	}
+	mine, err := s.rr.TypesByUser(ctx, blog.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &viewmodel.ReactionSummaryVM{
+		Title:       blog.Content.Title,
+		Reactions:   viewmodel.ToReactionCounts(blog.ReactionCounts),
+		MyReactions: mine,
+	}, nil
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
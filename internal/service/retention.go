@@ -0,0 +1,124 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const defaultRetentionBatchSize = 200
+
+type RetentionService interface {
+	// Preview, sıradaki purge turunun sileceği kayıtları raporlar (dry-run)
+	Preview(ctx context.Context) (*viewmodel.PurgeReportVM, error)
+	// Purge, saklama süresi dolan soft-delete kayıtları bağlı verileriyle kalıcı olarak siler (worker)
+	Purge(ctx context.Context) error
+}
+
+type retentionService struct {
+	rr  repository.RetentionRepository
+	cfg config.RetentionConfig
+}
+
+func NewRetentionService(rr repository.RetentionRepository, cfg config.RetentionConfig) RetentionService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultRetentionBatchSize
+	}
+	return &retentionService{rr: rr, cfg: cfg}
+}
+
+func (s *retentionService) Preview(ctx context.Context) (*viewmodel.PurgeReportVM, error) {
+	ctx, span := tracer.Start(ctx, "RetentionService.Preview")
+	defer span.End()
+
+	now := time.Now()
+	plan, err := s.rr.Plan(ctx, s.cutoffs(now), s.cfg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	report := s.report(plan, now)
+	report.DryRun = true
+	return report, nil
+}
+
+func (s *retentionService) Purge(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "RetentionService.Purge")
+	defer span.End()
+
+	now := time.Now()
+	plan, err := s.rr.Plan(ctx, s.cutoffs(now), s.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+	if plan.Empty() {
+		return nil
+	}
+
+	details, err := json.Marshal(s.report(plan, now))
+	if err != nil {
+		return err
+	}
+	return s.rr.Purge(ctx, plan, &entity.AuditLog{
+		Actor:      "system",
+		Action:     entity.AuditRetentionPurged,
+		TargetType: "retention",
+		Details:    string(details),
+		CreatedAt:  now,
+	})
+}
+
+func (s *retentionService) cutoffs(now time.Time) repository.RetentionCutoffs {
+	cutoff := func(d time.Duration) time.Time {
+		if d <= 0 {
+			return time.Time{}
+		}
+		return now.Add(-d)
+	}
+	return repository.RetentionCutoffs{
+		Users:         cutoff(s.cfg.Users),
+		Blogs:         cutoff(s.cfg.Blogs),
+		Comments:      cutoff(s.cfg.Comments),
+		Notifications: cutoff(s.cfg.Notifications),
+	}
+}
+
+func (s *retentionService) report(plan *repository.PurgePlan, now time.Time) *viewmodel.PurgeReportVM {
+	period := func(d time.Duration) string {
+		if d <= 0 {
+			return "disabled"
+		}
+		return d.String()
+	}
+	return &viewmodel.PurgeReportVM{
+		GeneratedAt: now.UTC(),
+		Retention: map[string]string{
+			"users":         period(s.cfg.Users),
+			"blogs":         period(s.cfg.Blogs),
+			"comments":      period(s.cfg.Comments),
+			"notifications": period(s.cfg.Notifications),
+		},
+		Users:           len(plan.UserIDs),
+		Blogs:           len(plan.BlogIDs),
+		Comments:        len(plan.CommentIDs),
+		Notifications:   len(plan.NotificationIDs),
+		Reactions:       plan.Reactions,
+		MediaLinks:      plan.MediaLinks,
+		Bookmarks:       plan.Bookmarks,
+		UserIDs:         nonNilIDs(plan.UserIDs),
+		BlogIDs:         nonNilIDs(plan.BlogIDs),
+		CommentIDs:      nonNilIDs(plan.CommentIDs),
+		NotificationIDs: nonNilIDs(plan.NotificationIDs),
+	}
+}
+
+// nonNilIDs, JSON'da null yerine [] görünmesi için
+func nonNilIDs(ids []uint) []uint {
+	if ids == nil {
+		return []uint{}
+	}
+	return ids
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/viewmodel"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	seoMetaTitleMax       = 120
+	seoMetaDescriptionMax = 320
+)
+
+// validateSEO alanları kırpar; URL alanları mutlak http(s) adresi olmalı
+func validateSEO(vm *viewmodel.SEOVM) error {
+	vm.MetaTitle = strings.TrimSpace(vm.MetaTitle)
+	vm.MetaDescription = strings.TrimSpace(vm.MetaDescription)
+	vm.CanonicalURL = strings.TrimSpace(vm.CanonicalURL)
+	vm.OGImage = strings.TrimSpace(vm.OGImage)
+
+	if utf8.RuneCountInString(vm.MetaTitle) > seoMetaTitleMax {
+		return Validation("seo_meta_title_too_long", "meta title en fazla 120 karakter olabilir")
+	}
+	if utf8.RuneCountInString(vm.MetaDescription) > seoMetaDescriptionMax {
+		return Validation("seo_meta_description_too_long", "meta description en fazla 320 karakter olabilir")
+	}
+	if vm.CanonicalURL != "" && !isAbsoluteHTTPURL(vm.CanonicalURL) {
+		return Validation("seo_invalid_canonical_url", "canonical url must be an absolute http(s) url")
+	}
+	if vm.OGImage != "" && !isAbsoluteHTTPURL(vm.OGImage) {
+		return Validation("seo_invalid_og_image", "og image must be an absolute http(s) url")
+	}
+	return nil
+}
+
+func isAbsoluteHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// sitemaps.org: bir sitemap dosyası en fazla 50.000 URL içerebilir
+const sitemapMaxURLs = 50000
+
+var ErrSitemapPageNotFound = NotFound("sitemap_page_not_found", "sitemap page not found")
+
+type SitemapService interface {
+	// Sitemap, URL sayısı sınırı aşarsa sitemap index döner
+	Sitemap(ctx context.Context) (*viewmodel.SitemapVM, error)
+	// SitemapPage, index içindeki 1'den başlayan alt sitemap
+	SitemapPage(ctx context.Context, page int) (*viewmodel.SitemapVM, error)
+}
+
+type sitemapService struct {
+	br  repository.BlogRepository
+	cfg config.SiteConfig
+}
+
+func NewSitemapService(br repository.BlogRepository, cfg config.SiteConfig) SitemapService {
+	return &sitemapService{br: br, cfg: cfg}
+}
+
+func (s *sitemapService) Sitemap(ctx context.Context) (*viewmodel.SitemapVM, error) {
+	ctx, span := tracer.Start(ctx, "SitemapService.Sitemap")
+	defer span.End()
+
+	total, err := s.br.CountPublished(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sitemap get error: %w", err)
+	}
+	if total <= sitemapMaxURLs {
+		return s.page(ctx, 0)
+	}
+
+	base := strings.TrimRight(s.cfg.BaseURL, "/")
+	pages := int((total + sitemapMaxURLs - 1) / sitemapMaxURLs)
+	vm := &viewmodel.SitemapVM{IsIndex: true, URLs: make([]viewmodel.SitemapURLVM, pages)}
+	for i := range vm.URLs {
+		vm.URLs[i] = viewmodel.SitemapURLVM{Loc: fmt.Sprintf("%s/sitemap-%d.xml", base, i+1)}
+	}
+	return vm, nil
+}
+
+func (s *sitemapService) SitemapPage(ctx context.Context, page int) (*viewmodel.SitemapVM, error) {
+	ctx, span := tracer.Start(ctx, "SitemapService.SitemapPage")
+	defer span.End()
+
+	if page < 1 {
+		return nil, ErrSitemapPageNotFound
+	}
+	vm, err := s.page(ctx, (page-1)*sitemapMaxURLs)
+	if err != nil {
+		return nil, err
+	}
+	if len(vm.URLs) == 0 {
+		return nil, ErrSitemapPageNotFound
+	}
+	return vm, nil
+}
+
+func (s *sitemapService) page(ctx context.Context, offset int) (*viewmodel.SitemapVM, error) {
+	entries, err := s.br.ListSitemapEntries(ctx, offset, sitemapMaxURLs)
+	if err != nil {
+		return nil, fmt.Errorf("sitemap get error: %w", err)
+	}
+	base := strings.TrimRight(s.cfg.BaseURL, "/")
+	vm := &viewmodel.SitemapVM{URLs: make([]viewmodel.SitemapURLVM, len(entries))}
+	for i, e := range entries {
+		vm.URLs[i] = viewmodel.SitemapURLVM{Loc: blogURL(base, e.Title), LastMod: e.UpdatedAt}
+	}
+	return vm, nil
+}
+
+// blogURL, feed ve sitemap'te kullanılan herkese açık blog adresi
+func blogURL(base, title string) string {
+	return base + "/blog/" + url.PathEscape(title)
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+var slugReplacer = strings.NewReplacer(
+	"ç", "c", "ğ", "g", "ı", "i", "ö", "o", "ş", "s", "ü", "u",
+	"Ç", "c", "Ğ", "g", "İ", "i", "Ö", "o", "Ş", "s", "Ü", "u",
+)
+
+// slugify: "Go İç Yapısı" -> "go-ic-yapisi"
+func slugify(s string) string {
+	s = strings.ToLower(slugReplacer.Replace(s))
+	var b strings.Builder
+	dash := false
+	for _, r := range s {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,19 @@
+package service
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Go İç Yapısı", "go-ic-yapisi"},
+		{"  Okuma Listem  ", "okuma-listem"},
+		{"Çok---Şey!!", "cok-sey"},
+		{"Hello, World 2024", "hello-world-2024"},
+		{"日本語", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := slugify(tt.in); got != tt.want {
+			t.Errorf("slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer, servis metotlarının span'leri; HTTP isteğinin span'i altına açılır
+var tracer = otel.Tracer("cleanArch_with_postgres/service")
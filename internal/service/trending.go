@@ -0,0 +1,70 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Pencereler ve yarılanma süreleri: bir etkileşimin etkisi HalfLife sonra yarıya iner
+var trendingWindows = []repository.TrendingWindow{
+	{Name: entity.TrendingDay, Length: 24 * time.Hour, HalfLife: 6 * time.Hour},
+	{Name: entity.TrendingWeek, Length: 7 * 24 * time.Hour, HalfLife: 36 * time.Hour},
+	{Name: entity.TrendingMonth, Length: 30 * 24 * time.Hour, HalfLife: 7 * 24 * time.Hour},
+}
+
+type TrendingService interface {
+	GetTrending(ctx context.Context, window string, limit int) ([]viewmodel.TrendingBlogVM, error)
+	// Refresh, arka plan işi tarafından periyodik çağrılır
+	Refresh(ctx context.Context) error
+}
+
+type trendingService struct {
+	tr  repository.TrendingRepository
+	cfg config.TrendingConfig
+}
+
+func NewTrendingService(tr repository.TrendingRepository, cfg config.TrendingConfig) TrendingService {
+	return &trendingService{tr: tr, cfg: cfg}
+}
+
+func (s *trendingService) GetTrending(ctx context.Context, window string, limit int) ([]viewmodel.TrendingBlogVM, error) {
+	ctx, span := tracer.Start(ctx, "TrendingService.GetTrending")
+	defer span.End()
+
+	if window == "" {
+		window = entity.TrendingWeek
+	}
+	if window != entity.TrendingDay && window != entity.TrendingWeek && window != entity.TrendingMonth {
+		return nil, Validation("invalid_trending_window", "invalid window, expected day|week|month")
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	rows, err := s.tr.Top(ctx, window, limit)
+	if err != nil {
+		return nil, fmt.Errorf("trending blogs get error: %w", err)
+	}
+	out := make([]viewmodel.TrendingBlogVM, len(rows))
+	for i := range rows {
+		out[i] = viewmodel.TrendingBlogVM{BlogVM: *viewmodel.ToBlogVM(&rows[i].Blog), Score: rows[i].Score}
+	}
+	return out, nil
+}
+
+func (s *trendingService) Refresh(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "TrendingService.Refresh")
+	defer span.End()
+
+	_, err := s.tr.Refresh(ctx, time.Now(), trendingWindows, repository.TrendingWeights{
+		View:     s.cfg.ViewWeight,
+		Reaction: s.cfg.ReactionWeight,
+		Comment:  s.cfg.CommentWeight,
+	})
+	return err
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/i18n"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	usernameRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.]{2,19}$`)
+	slugRe     = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+)
+
+var validate = newValidator()
+
+// newValidator, viewmodel'lerdeki `validate` tag'lerini okuyan validator'ı kurar.
+// Alan adları JSON adlarıyla raporlanır; username, slug, role, locale ve password özel kurallardır.
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+
+	rules := map[string]validator.Func{
+		"username": func(fl validator.FieldLevel) bool { return usernameRe.MatchString(fl.Field().String()) },
+		"slug":     func(fl validator.FieldLevel) bool { return slugRe.MatchString(fl.Field().String()) },
+		"role": func(fl validator.FieldLevel) bool {
+			switch entity.UserRole(strings.ToLower(fl.Field().String())) {
+			case entity.RoleReader, entity.RoleWriter, entity.RoleAdmin:
+				return true
+			}
+			return false
+		},
+		"locale": func(fl validator.FieldLevel) bool { return i18n.Supported(fl.Field().String()) },
+		// password: en az bir harf ve bir rakam; uzunluk min/max tag'leriyle
+		"password": func(fl validator.FieldLevel) bool {
+			var letter, digit bool
+			for _, r := range fl.Field().String() {
+				letter = letter || unicode.IsLetter(r)
+				digit = digit || unicode.IsDigit(r)
+			}
+			return letter && digit
+		},
+	}
+	for tag, fn := range rules {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(err)
+		}
+	}
+	return v
+}
+
+// Validate, struct tag'lerine göre input'u doğrular ve tüm alan hatalarını tek seferde döner
+func Validate(input interface{}) error {
+	err := validate.Struct(input)
+	if err == nil {
+		return nil
+	}
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return ErrInvalidInput.Wrap(err)
+	}
+
+	fields := make([]FieldError, 0, len(verrs))
+	names := make([]string, 0, len(verrs))
+	for _, fe := range verrs {
+		// "RegisterRequest.email" -> "email", iç içe struct'larda "seo.meta_title"
+		field := fe.Namespace()
+		if i := strings.IndexByte(field, '.'); i >= 0 {
+			field = field[i+1:]
+		}
+		key := "validation." + fe.Tag()
+		if (fe.Tag() == "min" || fe.Tag() == "max") && (fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map) {
+			key += "_items"
+		}
+		fields = append(fields, FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: ruleMessage(fe.Tag(), fe.Param(), fe.Kind()),
+			Key:     key,
+		})
+		names = append(names, field)
+	}
+
+	e := Validation("validation_failed", "invalid fields: "+strings.Join(names, ", ")).With("fields", strings.Join(names, ", "))
+	e.Fields = fields
+	return e
+}
+
+// ruleMessage, katalogda karşılığı olmayan kurallar için İngilizce yedek mesaj
+func ruleMessage(rule, param string, kind reflect.Kind) string {
+	unit := "characters"
+	if kind == reflect.Slice || kind == reflect.Map {
+		unit = "items"
+	}
+	switch rule {
+	case "required":
+		return "is required"
+	case "min":
+		return fmt.Sprintf("must be at least %s %s", param, unit)
+	case "max":
+		return fmt.Sprintf("must be at most %s %s", param, unit)
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(param, " ", ", ")
+	case "http_url":
+		return "must be an absolute http(s) url"
+	case "username":
+		return "must be 3-20 characters, start with a letter and contain only letters, digits, _ and ."
+	case "slug":
+		return "must contain only lowercase letters, digits and single dashes"
+	case "role":
+		return "must be one of: reader, writer, admin"
+	case "password":
+		return "must contain at least one letter and one digit"
+	case "locale":
+		return "must be a supported language"
+	}
+	return "is invalid"
+}
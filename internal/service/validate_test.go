@@ -0,0 +1,55 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/viewmodel"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	valid := viewmodel.RegisterRequest{Username: "ayse_k", Email: "ayse@example.com", Password: "parola123", Role: "writer"}
+	tests := []struct {
+		name   string
+		edit   func(r *viewmodel.RegisterRequest)
+		fields []string // boşsa geçerli
+	}{
+		{"valid", func(r *viewmodel.RegisterRequest) {}, nil},
+		{"valid locale", func(r *viewmodel.RegisterRequest) { r.Locale = "en" }, nil},
+		{"role case insensitive", func(r *viewmodel.RegisterRequest) { r.Role = "Admin" }, nil},
+		{"username too short", func(r *viewmodel.RegisterRequest) { r.Username = "ab" }, []string{"username"}},
+		{"username starts with digit", func(r *viewmodel.RegisterRequest) { r.Username = "1ayse" }, []string{"username"}},
+		{"username with dash", func(r *viewmodel.RegisterRequest) { r.Username = "ayse-k" }, []string{"username"}},
+		{"bad email", func(r *viewmodel.RegisterRequest) { r.Email = "ayse" }, []string{"email"}},
+		{"password without digit", func(r *viewmodel.RegisterRequest) { r.Password = "parolaparola" }, []string{"password"}},
+		{"password too short", func(r *viewmodel.RegisterRequest) { r.Password = "a1" }, []string{"password"}},
+		{"unknown role", func(r *viewmodel.RegisterRequest) { r.Role = "owner" }, []string{"role"}},
+		{"unknown locale", func(r *viewmodel.RegisterRequest) { r.Locale = "xx" }, []string{"locale"}},
+		{"all fields reported", func(r *viewmodel.RegisterRequest) { *r = viewmodel.RegisterRequest{} },
+			[]string{"username", "email", "password", "role"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.edit(&req)
+			err := Validate(req)
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var e *Error
+			if !errors.As(err, &e) || e.Kind != KindValidation {
+				t.Fatalf("err = %v, want validation error", err)
+			}
+			var got []string
+			for _, f := range e.Fields {
+				got = append(got, f.Field)
+			}
+			if !reflect.DeepEqual(got, tt.fields) {
+				t.Errorf("fields = %v, want %v", got, tt.fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/infrastructure/config"
+	"testing"
+	"time"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	// beklenen değerler bağımsız bir HMAC-SHA256 implementasyonuyla hesaplandı
+	tests := []struct {
+		secret, timestamp, body string
+		want                    string
+	}{
+		{"whsec_test", "1700000000", `{"event":"blog.published"}`, "3b5caeee5711401cb23be0640dabd468165792618003c58c6dc6653c5c308e2e"},
+		{"key", "", "", "e79781879bd7bc04eb8d3e4abb8e734486c2a0f4d9643ae29fdead53cd551f9a"},
+		{"", "0", "", "b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3"},
+	}
+	for _, tt := range tests {
+		if got := SignWebhookPayload(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+			t.Errorf("SignWebhookPayload(%q, %q, %q) = %s, want %s", tt.secret, tt.timestamp, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	s := &webhookService{cfg: config.WebhookConfig{BaseBackoff: 30 * time.Second, MaxBackoff: 10 * time.Minute}}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{5, 8 * time.Minute},
+		{6, 10 * time.Minute},
+		{7, 10 * time.Minute},
+		// kaydırma taşması olmamalı
+		{100, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := s.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookClaimLease(t *testing.T) {
+	// batch sırayla gönderilir; lease en kötü durumda (her istek timeout'a kadar sürer) dolmamalı
+	for _, timeout := range []time.Duration{time.Second, 10 * time.Second, time.Minute} {
+		s := &webhookService{cfg: config.WebhookConfig{Timeout: timeout}}
+		if got, floor := s.claimLease(), webhookClaimBatch*timeout; got <= floor {
+			t.Errorf("timeout %v: lease %v, want > %v", timeout, got, floor)
+		}
+	}
+}
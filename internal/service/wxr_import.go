@@ -0,0 +1,440 @@
+package service
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"cleanArch_with_postgres/internal/infrastructure/render"
+	"cleanArch_with_postgres/internal/infrastructure/wxr"
+	"cleanArch_with_postgres/internal/repository"
+	"cleanArch_with_postgres/internal/viewmodel"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// WordPress'te tip kavramı yok; aktarılan yazılar bu tiple oluşturulur
+const wxrDefaultBlogType = "note"
+
+var wpBlockStart = regexp.MustCompile(`(?i)^<(p|div|h[1-6]|ul|ol|li|blockquote|pre|table|figure|hr|img|iframe|!--)[\s>/]`)
+
+type ImportService interface {
+	// ImportWXR, WordPress export'unu aktarır. Tekrar çalıştırıldığında önceden
+	// aktarılan kayıtlar eşleşme tablosundan bulunup atlanır.
+	ImportWXR(ctx context.Context, r io.Reader) (*viewmodel.WXRImportReportVM, error)
+}
+
+type importService struct {
+	ir repository.ImportRepository
+	ur repository.UserRepository
+	br repository.BlogRepository
+}
+
+func NewImportService(ir repository.ImportRepository, ur repository.UserRepository, br repository.BlogRepository) ImportService {
+	return &importService{ir: ir, ur: ur, br: br}
+}
+
+type importedUser struct {
+	id       uint
+	username string
+}
+
+// wxrRun, tek bir import çalıştırmasının durumu
+type wxrRun struct {
+	s       *importService
+	site    string
+	report  *viewmodel.WXRImportReportVM
+	byLogin map[string]importedUser // wp author_login
+	byWPID  map[string]importedUser // wp author_id (comment_user_id ile eşleşir)
+	byEmail map[string]importedUser
+}
+
+func (s *importService) ImportWXR(ctx context.Context, r io.Reader) (*viewmodel.WXRImportReportVM, error) {
+	ctx, span := tracer.Start(ctx, "ImportService.ImportWXR")
+	defer span.End()
+
+	run := &wxrRun{
+		s:       s,
+		report:  &viewmodel.WXRImportReportVM{Warnings: []string{}},
+		byLogin: map[string]importedUser{},
+		byWPID:  map[string]importedUser{},
+		byEmail: map[string]importedUser{},
+	}
+	err := wxr.Decode(r, func(v interface{}) error {
+		switch x := v.(type) {
+		case *wxr.Site:
+			run.site = strings.TrimRight(strings.TrimSpace(x.Link), "/")
+			if run.site == "" {
+				run.site = strings.TrimSpace(x.Title)
+			}
+			run.report.Site = run.site
+		case *wxr.Author:
+			return run.author(ctx, x)
+		case *wxr.Category:
+			run.report.Categories++
+		case *wxr.Tag:
+			run.report.Tags++
+		case *wxr.Item:
+			return run.item(ctx, x)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, wxr.ErrNotWXR) {
+			return run.report, err
+		}
+		return run.report, fmt.Errorf("wxr import: %w", err)
+	}
+	return run.report, nil
+}
+
+func (run *wxrRun) warn(format string, args ...interface{}) {
+	run.report.Warnings = append(run.report.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (run *wxrRun) author(ctx context.Context, a *wxr.Author) error {
+	login := strings.TrimSpace(a.Login)
+	if login == "" {
+		run.report.Users.Skipped++
+		return nil
+	}
+	u, err := run.ensureUser(ctx, "login:"+login, login, a.Email)
+	if err != nil {
+		return err
+	}
+	run.byLogin[login] = u
+	if a.ID != "" {
+		run.byWPID[a.ID] = u
+	}
+	return nil
+}
+
+// ensureUser: önce eşleşme tablosu, sonra e-posta ile mevcut kullanıcı; yoksa şifre
+// sıfırlaması zorunlu bir okuyucu hesabı açılır
+func (run *wxrRun) ensureUser(ctx context.Context, sourceID, login, email string) (importedUser, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if u, ok := run.byEmail[email]; ok && email != "" {
+		return u, nil
+	}
+
+	id, found, err := run.s.ir.FindTarget(ctx, entity.ImportSourceWXR, run.site, entity.ImportKindUser, sourceID)
+	if err != nil {
+		return importedUser{}, err
+	}
+	if found {
+		user, err := run.s.ur.GetByID(ctx, id)
+		if err == nil {
+			run.report.Users.Existing++
+			return run.remember(email, user), nil
+		}
+		// eşleşen kullanıcı sonradan silinmiş: yeniden oluşturulur
+	}
+
+	if email == "" {
+		email = fmt.Sprintf("%s@wxr-import.invalid", slugify(login))
+	}
+	mapping := &entity.ImportMapping{
+		Source:     entity.ImportSourceWXR,
+		SourceSite: run.site,
+		Kind:       entity.ImportKindUser,
+		SourceID:   sourceID,
+		CreatedAt:  time.Now(),
+	}
+	if existing, err := run.s.ur.GetByIdentifier(ctx, email); err == nil && existing != nil {
+		mapping.TargetID = existing.ID
+		if err := run.s.ir.SaveMapping(ctx, mapping); err != nil {
+			return importedUser{}, err
+		}
+		run.report.Users.Existing++
+		return run.remember(email, existing), nil
+	}
+
+	username, err := run.freeUsername(ctx, login)
+	if err != nil {
+		return importedUser{}, err
+	}
+	password, err := randomHex(24) // kimse bilmiyor; kullanıcı şifre sıfırlamadan giriş yapamaz
+	if err != nil {
+		return importedUser{}, err
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return importedUser{}, err
+	}
+	user := &entity.User{
+		Username:          username,
+		Email:             email,
+		Password:          string(hashed),
+		Role:              entity.RoleReader,
+		Followers:         []string{},
+		MustResetPassword: true,
+	}
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	if err := run.s.ir.CreateUser(ctx, user, mapping); err != nil {
+		return importedUser{}, fmt.Errorf("user %q create error: %w", username, err)
+	}
+	run.report.Users.Created++
+	return run.remember(email, user), nil
+}
+
+func (run *wxrRun) remember(email string, u *entity.User) importedUser {
+	iu := importedUser{id: u.ID, username: u.Username}
+	if email != "" {
+		run.byEmail[email] = iu
+	}
+	return iu
+}
+
+// freeUsername, login'i kullanıcı adı kurallarına uydurur; alınmışsa sonuna _2, _3... ekler
+func (run *wxrRun) freeUsername(ctx context.Context, login string) (string, error) {
+	base := wpUsername(login)
+	candidate := base
+	for i := 2; i < 1000; i++ {
+		if _, err := run.s.ur.GetByUsername(ctx, candidate); err != nil {
+			return candidate, nil
+		}
+		suffix := fmt.Sprintf("_%d", i)
+		candidate = strings.TrimRight(truncate(base, usernameMaxLen-len(suffix)), "_.") + suffix
+	}
+	return "", fmt.Errorf("no free username for %q", login)
+}
+
+const (
+	usernameMinLen = 3
+	usernameMaxLen = 20
+)
+
+// wpUsername, WordPress login'ini usernameRe alfabesine çevirir: Türkçe harfler ASCII'ye, diğer
+// karakterler (-, boşluk, @) _ olur; harfle başlamıyor ya da çok kısaysa "wp_" öneki alır.
+// "Ayşe Yılmaz" -> "ayse_yilmaz", "john@example.com" -> "john_example.com", "42" -> "wp_42"
+func wpUsername(login string) string {
+	login = strings.ToLower(slugReplacer.Replace(strings.TrimSpace(login)))
+	var b strings.Builder
+	sep := false
+	for _, r := range login {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.':
+			b.WriteRune(r)
+			sep = false
+		case !sep && b.Len() > 0:
+			b.WriteByte('_')
+			sep = true
+		}
+	}
+	name := strings.TrimRight(b.String(), "_.")
+	if name == "" {
+		return "wp_user"
+	}
+	if name[0] < 'a' || name[0] > 'z' || len(name) < usernameMinLen {
+		name = "wp_" + strings.TrimLeft(name, "_.")
+	}
+	return strings.TrimRight(truncate(name, usernameMaxLen), "_.")
+}
+
+// truncate, ASCII string'i en fazla n byte'a kısaltır
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func (run *wxrRun) item(ctx context.Context, it *wxr.Item) error {
+	if it.PostType != "post" { // sayfalar, ekler, menüler vs.
+		return nil
+	}
+	if it.Status == "trash" || it.Status == "auto-draft" || it.Status == "inherit" {
+		run.report.Posts.Skipped++
+		return nil
+	}
+
+	blogID, found, err := run.s.ir.FindTarget(ctx, entity.ImportSourceWXR, run.site, entity.ImportKindPost, it.PostID)
+	if err != nil {
+		return err
+	}
+	if found {
+		run.report.Posts.Existing++
+	} else {
+		blogID, err = run.createPost(ctx, it)
+		if err != nil {
+			return err
+		}
+		if blogID == 0 {
+			run.report.Posts.Skipped++
+			return nil
+		}
+		run.report.Posts.Created++
+	}
+
+	for i := range it.Comments {
+		if err := run.comment(ctx, blogID, &it.Comments[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (run *wxrRun) createPost(ctx context.Context, it *wxr.Item) (uint, error) {
+	login := strings.TrimSpace(it.Creator)
+	author, ok := run.byLogin[login]
+	if !ok {
+		// WXR 1.0 export'larında wp:author listesi olmayabilir
+		u, err := run.ensureUser(ctx, "login:"+login, login, "")
+		if err != nil {
+			return 0, err
+		}
+		run.byLogin[login] = u
+		author = u
+	}
+
+	title := strings.TrimSpace(it.Title)
+	if title == "" {
+		title = fmt.Sprintf("Untitled %s", it.PostID)
+	}
+	// başlık bizde blog anahtarı gibi kullanılıyor, çakışırsa ayırt et
+	if existing, err := run.s.br.GetBlogByTitle(ctx, title); err == nil && existing != nil {
+		newTitle := fmt.Sprintf("%s (%s)", title, it.PostID)
+		run.warn("post %s: title %q already exists, imported as %q", it.PostID, title, newTitle)
+		title = newTitle
+	}
+
+	body := wpAutoP(it.Content)
+	if strings.TrimSpace(body) == "" {
+		run.warn("post %s: empty content, skipped", it.PostID)
+		return 0, nil
+	}
+
+	status := entity.BlogStatusDraft
+	if it.Status == "publish" {
+		status = entity.BlogStatusPublished
+	}
+	category := ""
+	if cats := it.Categories(); len(cats) > 0 {
+		category = cats[0]
+		if len(cats) > 1 {
+			run.warn("post %s: only the first of %d categories kept (%s)", it.PostID, len(cats), category)
+		}
+	}
+
+	blog := &entity.Blog{
+		Content: entity.Content{
+			Title:      title,
+			Body:       body,
+			BodyFormat: entity.BodyFormatHTML,
+			AuthorID:   int(author.id),
+			Username:   author.username,
+			Type:       wxrDefaultBlogType,
+			IsApproved: status == entity.BlogStatusPublished, // admin tarafından aktarılıyor
+			Status:     status,
+		},
+		Tags:     strings.Join(it.Tags(), ","),
+		Category: category,
+	}
+	blog.CreatedAt = it.Published()
+	if blog.CreatedAt.IsZero() {
+		blog.CreatedAt = time.Now()
+	}
+	blog.UpdatedAt = it.Modified()
+	if blog.UpdatedAt.IsZero() {
+		blog.UpdatedAt = blog.CreatedAt
+	}
+	if err := render.Apply(&blog.Content); err != nil {
+		return 0, err
+	}
+
+	mapping := &entity.ImportMapping{
+		Source:     entity.ImportSourceWXR,
+		SourceSite: run.site,
+		Kind:       entity.ImportKindPost,
+		SourceID:   it.PostID,
+		CreatedAt:  time.Now(),
+	}
+	if err := run.s.ir.CreateBlog(ctx, blog, mapping); err != nil {
+		return 0, fmt.Errorf("post %s create error: %w", it.PostID, err)
+	}
+	return blog.ID, nil
+}
+
+func (run *wxrRun) comment(ctx context.Context, blogID uint, c *wxr.Comment) error {
+	// pingback/trackback ve onaylanmamış (spam dahil) yorumlar alınmaz
+	if c.Approved != "1" || (c.Type != "" && c.Type != "comment") {
+		run.report.Comments.Skipped++
+		return nil
+	}
+	_, found, err := run.s.ir.FindTarget(ctx, entity.ImportSourceWXR, run.site, entity.ImportKindComment, c.ID)
+	if err != nil {
+		return err
+	}
+	if found {
+		run.report.Comments.Existing++
+		return nil
+	}
+
+	var user importedUser
+	if u, ok := run.byWPID[c.UserID]; ok && c.UserID != "0" {
+		user = u
+	} else if strings.TrimSpace(c.AuthorEmail) != "" {
+		login := strings.TrimSpace(c.Author)
+		if login == "" {
+			login = strings.SplitN(c.AuthorEmail, "@", 2)[0]
+		}
+		user, err = run.ensureUser(ctx, "email:"+strings.ToLower(strings.TrimSpace(c.AuthorEmail)), login, c.AuthorEmail)
+		if err != nil {
+			return err
+		}
+	} else {
+		run.warn("comment %s: anonymous comment without email, skipped", c.ID)
+		run.report.Comments.Skipped++
+		return nil
+	}
+
+	comment := &entity.Comment{
+		BlogID:  int(blogID),
+		UserID:  int(user.id),
+		Content: strings.TrimSpace(c.Content),
+	}
+	if t, ok := wxr.ParseDate(c.DateGMT); ok {
+		comment.CreatedAt, comment.UpdatedAt = t, t
+	} else {
+		comment.CreatedAt, comment.UpdatedAt = time.Now(), time.Now()
+	}
+	mapping := &entity.ImportMapping{
+		Source:     entity.ImportSourceWXR,
+		SourceSite: run.site,
+		Kind:       entity.ImportKindComment,
+		SourceID:   c.ID,
+		CreatedAt:  time.Now(),
+	}
+	if err := run.s.ir.CreateComment(ctx, comment, mapping); err != nil {
+		return fmt.Errorf("comment %s create error: %w", c.ID, err)
+	}
+	run.report.Comments.Created++
+	return nil
+}
+
+// wpAutoP, WordPress'in görüntülerken uyguladığı paragraflamanın sade hali:
+// boş satırla ayrılan ve blok etiketle başlamayan parçalar <p> içine alınır
+func wpAutoP(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	var b strings.Builder
+	for _, chunk := range strings.Split(content, "\n\n") {
+		chunk = strings.TrimSpace(chunk)
+		if chunk == "" {
+			continue
+		}
+		if wpBlockStart.MatchString(chunk) {
+			b.WriteString(chunk)
+		} else {
+			b.WriteString("<p>")
+			b.WriteString(strings.ReplaceAll(chunk, "\n", "<br>\n"))
+			b.WriteString("</p>")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
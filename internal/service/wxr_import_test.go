@@ -0,0 +1,38 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWPUsername(t *testing.T) {
+	tests := []struct{ login, want string }{
+		{"admin", "admin"},
+		{"Ayşe Yılmaz", "ayse_yilmaz"},
+		{"john-doe", "john_doe"},
+		{"john@example.com", "john_example.com"},
+		{"  --mary--  ", "mary"},
+		{"42", "wp_42"},
+		{"_x", "wp_x"},
+		{"ab", "wp_ab"},
+		{"", "wp_user"},
+		{"@@@", "wp_user"},
+		{"日本", "wp_user"},
+		{"a-very-long-wordpress-login-name", "a_very_long_wordpres"},
+		{"abcdefghijklmnopqrs_tuv", "abcdefghijklmnopqrs"},
+	}
+	for _, tt := range tests {
+		got := wpUsername(tt.login)
+		if got != tt.want {
+			t.Errorf("wpUsername(%q) = %q, want %q", tt.login, got, tt.want)
+		}
+		if !usernameRe.MatchString(got) {
+			t.Errorf("wpUsername(%q) = %q does not match usernameRe", tt.login, got)
+		}
+		// suffix eklenmiş hali de geçerli olmalı
+		suffixed := strings.TrimRight(truncate(got, usernameMaxLen-4), "_.") + "_999"
+		if !usernameRe.MatchString(suffixed) {
+			t.Errorf("suffixed username %q does not match usernameRe", suffixed)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package viewmodel
+
+type AnalyticsTotalsVM struct {
+	Views         int64 `json:"views"`
+	UniqueReaders int64 `json:"unique_readers"`
+	Reactions     int64 `json:"reactions"`
+	Comments      int64 `json:"comments"`
+}
+
+// DailyViewsVM: unique_readers, blog bazındaki günlük tekil okuyucuların toplamıdır
+type DailyViewsVM struct {
+	Date          string `json:"date"`
+	Views         int64  `json:"views"`
+	UniqueReaders int64  `json:"unique_readers"`
+}
+
+type BlogAnalyticsVM struct {
+	BlogID        uint   `json:"blog_id"`
+	Title         string `json:"title"`
+	Username      string `json:"username"`
+	Views         int64  `json:"views"`
+	UniqueReaders int64  `json:"unique_readers"`
+	Reactions     int64  `json:"reactions"`
+	Comments      int64  `json:"comments"`
+}
+
+type AnalyticsReportVM struct {
+	From   string            `json:"from"`
+	To     string            `json:"to"`
+	Author string            `json:"author,omitempty"` // boşsa site geneli
+	Totals AnalyticsTotalsVM `json:"totals"`
+	Daily  []DailyViewsVM    `json:"daily"`
+	Blogs  []BlogAnalyticsVM `json:"blogs"`
+}
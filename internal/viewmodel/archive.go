@@ -0,0 +1,22 @@
+package viewmodel
+
+// Import sonucu dosya bazında raporlanır; bir dosyanın hatası diğerlerini durdurmaz
+const (
+	ImportStatusCreated = "created"
+	ImportStatusFailed  = "failed"
+	ImportStatusSkipped = "skipped"
+)
+
+type ImportFileResultVM struct {
+	File   string `json:"file"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImportReportVM struct {
+	Created int                  `json:"created"`
+	Failed  int                  `json:"failed"`
+	Skipped int                  `json:"skipped"`
+	Files   []ImportFileResultVM `json:"files"`
+}
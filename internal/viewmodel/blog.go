@@ -8,67 +8,103 @@ import (
 )
 
 type BlogVM struct {
-	ID         uint           `json:"id"`
-	Title      string         `json:"title"`
-	Body       string         `json:"body"`
-	Type       string         `json:"type"`
-	AuthorID   int            `json:"author_id"`
-	Username   string         `json:"username"`
-	Tags       string         `json:"tags"`
-	Category   string         `json:"category"`
-	Comments   []CommentVM    `json:"comments"`
-	IsApproved bool           `json:"is_approved"`
-	Status     string         `json:"status"`
-	CreatedAt  time.Time      `json:"createdAt"`
-	UpdatedAt  time.Time      `json:"updatedAt"`
-	DeletedAt  gorm.DeletedAt `json:"deletedAt"`
+	ID         uint             `json:"id"`
+	Title      string           `json:"title"`
+	Body       string           `json:"body"`
+	BodyFormat string           `json:"body_format"`
+	Type       string           `json:"type"`
+	AuthorID   int              `json:"author_id"`
+	Username   string           `json:"username"`
+	Tags       string           `json:"tags"`
+	Category   string           `json:"category"`
+	Comments   []CommentVM      `json:"comments"`
+	Reactions  map[string]int64 `json:"reactions"` // tip -> adet
+	IsApproved bool             `json:"is_approved"`
+	Status     string           `json:"status"`
+	SEO        SEOVM            `json:"seo"`
+
+	// Sunucuda Body'den türetilir
+	BodyHTML    string       `json:"body_html"`
+	Excerpt     string       `json:"excerpt"`
+	WordCount   int          `json:"word_count"`
+	ReadingTime int          `json:"reading_time"` // dakika
+	TOC         []TOCEntryVM `json:"toc"`
+
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt gorm.DeletedAt `json:"deletedAt"`
 }
 
 type BlogCreateVM struct {
-	Title    string `json:"title"`
-	Body     string `json:"body"`
-	Type     string `json:"type"`
-	Tags     string `json:"tags"`
-	Category string `json:"category"`
-	Status   string `json:"status"`
+	Title      string `json:"title" validate:"required,max=200"`
+	Body       string `json:"body" validate:"required"`
+	BodyFormat string `json:"body_format" validate:"omitempty,oneof=markdown html plain"` // boşsa plain
+	Type       string `json:"type" validate:"required,max=50"`
+	Tags       string `json:"tags" validate:"required,max=255"`
+	Category   string `json:"category" validate:"required,max=100"`
+	Status     string `json:"status" validate:"required,oneof=draft published"`
+	SEO        SEOVM  `json:"seo"`
 }
 
 type BlogUpdateVM struct {
-	Title    string `json:"title"`
-	Body     string `json:"body"`
-	Type     string `json:"type"`
-	Tags     string `json:"tags"`
-	Category string `json:"category"`
-	Status   string `json:"status"`
+	Title      string `json:"title" validate:"required,max=200"`
+	Body       string `json:"body" validate:"required"`
+	BodyFormat string `json:"body_format" validate:"omitempty,oneof=markdown html plain"` // boşsa mevcut format
+	Type       string `json:"type" validate:"max=50"`
+	Tags       string `json:"tags" validate:"max=255"`
+	Category   string `json:"category" validate:"max=100"`
+	Status     string `json:"status" validate:"omitempty,oneof=draft published"`
+	SEO        SEOVM  `json:"seo"`
 }
 
 type BlogUpdateResponse struct {
 	Username  string    `json:"username"`
 	Title     string    `json:"title"`
 	Body      string    `json:"body"`
+	BodyHTML  string    `json:"body_html"`
 	Type      string    `json:"type"`
 	Tags      string    `json:"tags"`
 	Category  string    `json:"category"`
 	Status    string    `json:"status"`
+	SEO       SEOVM     `json:"seo"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// SEOVM, boş bırakılan alanlarda istemci başlık/özet gibi varsayılanları kullanır; kırpma ve
+// uzunluk kontrolü service'te validateSEO ile yapılır
+type SEOVM struct {
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+	CanonicalURL    string `json:"canonical_url" validate:"omitempty,http_url"`
+	OGImage         string `json:"og_image" validate:"omitempty,http_url"`
+}
+
 func ToBlogVM(b *entity.Blog) *BlogVM {
 	return &BlogVM{
 		ID:         b.ID,
 		Title:      b.Content.Title,
 		Body:       b.Content.Body,
+		BodyFormat: b.Content.BodyFormat,
 		Type:       b.Content.Type,
 		AuthorID:   b.Content.AuthorID,
 		Username:   b.Content.Username,
 		Tags:       b.Tags,
 		Category:   b.Category,
 		Comments:   ToCommentVMs(b.Comments),
+		Reactions:  ToReactionCounts(b.ReactionCounts),
 		IsApproved: b.Content.IsApproved,
 		Status:     b.Content.Status,
-		CreatedAt:  b.CreatedAt,
-		UpdatedAt:  b.UpdatedAt,
-		DeletedAt:  b.DeletedAt,
+		SEO:        ToSEOVM(b.SEO),
+
+		BodyHTML:    b.Content.BodyHTML,
+		Excerpt:     b.Content.Excerpt,
+		WordCount:   b.Content.WordCount,
+		ReadingTime: b.Content.ReadingTime,
+		TOC:         ToTOCVM(b.Content.TOC),
+
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+		DeletedAt: b.DeletedAt,
 	}
 }
 
@@ -79,3 +115,35 @@ func ToBlogVMs(blogs []entity.Blog) []BlogVM {
 	}
 	return vms
 }
+
+type TOCEntryVM struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+func ToTOCVM(toc entity.TOC) []TOCEntryVM {
+	out := make([]TOCEntryVM, len(toc))
+	for i, e := range toc {
+		out[i] = TOCEntryVM{Level: e.Level, ID: e.ID, Text: e.Text}
+	}
+	return out
+}
+
+func ToSEOVM(s entity.BlogSEO) SEOVM {
+	return SEOVM{
+		MetaTitle:       s.MetaTitle,
+		MetaDescription: s.MetaDescription,
+		CanonicalURL:    s.CanonicalURL,
+		OGImage:         s.OGImage,
+	}
+}
+
+func (vm SEOVM) ToEntity() entity.BlogSEO {
+	return entity.BlogSEO{
+		MetaTitle:       vm.MetaTitle,
+		MetaDescription: vm.MetaDescription,
+		CanonicalURL:    vm.CanonicalURL,
+		OGImage:         vm.OGImage,
+	}
+}
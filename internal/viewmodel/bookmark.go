@@ -0,0 +1,76 @@
+package viewmodel
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"time"
+)
+
+type BookmarkCreateVM struct {
+	Title         string `json:"title" validate:"required"` // blog başlığı
+	ReadingListID uint   `json:"reading_list_id"`
+	Note          string `json:"note" validate:"max=1000"`
+}
+
+type BookmarkUpdateVM struct {
+	ReadingListID *uint   `json:"reading_list_id"`
+	Note          *string `json:"note" validate:"omitnil,max=1000"`
+}
+
+// BookmarkVM: blog silinmiş veya onayı kaldırılmışsa Available false olur, Blog boş döner ve
+// Title kayıt anındaki başlıktır
+type BookmarkVM struct {
+	ID                uint      `json:"id"`
+	BlogID            uint      `json:"blog_id"`
+	Title             string    `json:"title"`
+	ReadingListID     uint      `json:"reading_list_id"`
+	Note              string    `json:"note"`
+	Available         bool      `json:"available"`
+	UnavailableReason string    `json:"unavailable_reason,omitempty"` // deleted | unapproved
+	Blog              *BlogVM   `json:"blog,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type ReadingListCreateVM struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=1000"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+type ReadingListUpdateVM struct {
+	Name        *string `json:"name" validate:"omitnil,min=1,max=100"`
+	Description *string `json:"description" validate:"omitnil,max=1000"`
+	IsPublic    *bool   `json:"is_public"`
+}
+
+type ReadingListVM struct {
+	ID          uint         `json:"id"`
+	Username    string       `json:"username"`
+	Name        string       `json:"name"`
+	Slug        string       `json:"slug"`
+	Description string       `json:"description"`
+	IsPublic    bool         `json:"is_public"`
+	Items       []BookmarkVM `json:"items,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+func ToReadingListVM(l *entity.ReadingList) *ReadingListVM {
+	return &ReadingListVM{
+		ID:          l.ID,
+		Username:    l.Username,
+		Name:        l.Name,
+		Slug:        l.Slug,
+		Description: l.Description,
+		IsPublic:    l.IsPublic,
+		CreatedAt:   l.CreatedAt,
+		UpdatedAt:   l.UpdatedAt,
+	}
+}
+
+func ToReadingListVMs(ls []entity.ReadingList) []ReadingListVM {
+	out := make([]ReadingListVM, len(ls))
+	for i := range ls {
+		out[i] = *ToReadingListVM(&ls[i])
+	}
+	return out
+}
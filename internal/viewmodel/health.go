@@ -0,0 +1,16 @@
+package viewmodel
+
+// HealthVM, /healthz ve /readyz cevabı. Checks'te sadece durum yazılır, hata detayı loglanır
+// (endpoint'ler JWT'siz, bağlantı bilgisi dışarı sızmasın).
+type HealthVM struct {
+	Status string            `json:"status"` // "ok" veya "unavailable"
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type VersionVM struct {
+	Commit        string `json:"commit"`
+	BuildTime     string `json:"build_time,omitempty"`
+	GoVersion     string `json:"go_version"`
+	Modified      bool   `json:"modified"`
+	SchemaVersion int    `json:"schema_version"` // DB'de uygulanmış son migration versiyonu
+}
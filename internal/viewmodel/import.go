@@ -0,0 +1,18 @@
+package viewmodel
+
+// ImportCountVM: Existing, önceki bir çalıştırmada aktarılmış (eşleşme tablosunda bulunan) kayıtlar
+type ImportCountVM struct {
+	Created  int `json:"created"`
+	Existing int `json:"existing"`
+	Skipped  int `json:"skipped"`
+}
+
+type WXRImportReportVM struct {
+	Site       string        `json:"site"`
+	Users      ImportCountVM `json:"users"`
+	Posts      ImportCountVM `json:"posts"`
+	Comments   ImportCountVM `json:"comments"`
+	Categories int           `json:"categories"`
+	Tags       int           `json:"tags"`
+	Warnings   []string      `json:"warnings"`
+}
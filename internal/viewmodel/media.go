@@ -0,0 +1,39 @@
+package viewmodel
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"time"
+)
+
+// MediaVM: URL blog gövdesine (markdown/html) eklenecek adres
+type MediaVM struct {
+	ID           uint      `json:"id"`
+	URL          string    `json:"url"`
+	ThumbURL     string    `json:"thumb_url"`
+	OriginalName string    `json:"original_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type MediaListVM struct {
+	Items []MediaVM `json:"items"`
+	Used  int64     `json:"used"`  // byte
+	Quota int64     `json:"quota"` // byte
+}
+
+func ToMediaVM(m *entity.Media, urlFor func(key string) string) MediaVM {
+	return MediaVM{
+		ID:           m.ID,
+		URL:          urlFor(m.Key),
+		ThumbURL:     urlFor(m.ThumbKey),
+		OriginalName: m.OriginalName,
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Width:        m.Width,
+		Height:       m.Height,
+		CreatedAt:    m.CreatedAt,
+	}
+}
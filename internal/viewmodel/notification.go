@@ -0,0 +1,34 @@
+package viewmodel
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"time"
+)
+
+type NotificationVM struct {
+	ID        uint       `json:"id"`
+	Type      string     `json:"type"`
+	Message   string     `json:"message"`
+	Link      string     `json:"link"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func ToNotificationVM(n *entity.Notification) *NotificationVM {
+	return &NotificationVM{
+		ID:        n.ID,
+		Type:      n.Type,
+		Message:   n.Message,
+		Link:      n.Link,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func ToNotificationVMs(ns []entity.Notification) []NotificationVM {
+	out := make([]NotificationVM, len(ns))
+	for i := range ns {
+		out[i] = *ToNotificationVM(&ns[i])
+	}
+	return out
+}
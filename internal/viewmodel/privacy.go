@@ -0,0 +1,47 @@
+package viewmodel
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"time"
+)
+
+// PersonalDataVM, kullanıcının kendisine ait tüm verilerinin JSON export'u
+type PersonalDataVM struct {
+	ExportedAt   time.Time         `json:"exported_at"`
+	Profile      UserVM            `json:"profile"`
+	Posts        []BlogVM          `json:"posts"`
+	Comments     []CommentVM       `json:"comments"`
+	Reactions    []UserReactionVM  `json:"reactions"`
+	RoleRequests []RoleRequestVM   `json:"role_requests"`
+	Erasure      *ErasureRequestVM `json:"erasure_request,omitempty"`
+}
+
+type UserReactionVM struct {
+	BlogID    uint      `json:"blog_id"`
+	BlogTitle string    `json:"blog_title"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ErasureConfirmVM, hesap silme talebi için şifre onayı
+type ErasureConfirmVM struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type ErasureRequestVM struct {
+	ID          uint       `json:"id"`
+	Status      string     `json:"status"`
+	ScheduledAt time.Time  `json:"scheduled_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func ToErasureRequestVM(r *entity.ErasureRequest) *ErasureRequestVM {
+	return &ErasureRequestVM{
+		ID:          r.ID,
+		Status:      r.Status,
+		ScheduledAt: r.ScheduledAt,
+		CompletedAt: r.CompletedAt,
+		CreatedAt:   r.CreatedAt,
+	}
+}
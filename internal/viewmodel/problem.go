@@ -0,0 +1,36 @@
+package viewmodel
+
+import "net/http"
+
+// ProblemContentType, RFC 7807 hata cevaplarının content type'ı
+const ProblemContentType = "application/problem+json"
+
+// ProblemVM, RFC 7807 problem details gövdesi. Code client'ların dayanabileceği sabit anahtar.
+type ProblemVM struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Code     string         `json:"code"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   []FieldErrorVM `json:"errors,omitempty"` // validation hatalarında alan bazlı detaylar
+	Data     interface{}    `json:"data,omitempty"`   // ör. yarıda kalan import'un raporu
+}
+
+type FieldErrorVM struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+func NewProblem(status int, code, detail, instance string) *ProblemVM {
+	return &ProblemVM{
+		Type:     "/errors/" + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Code:     code,
+		Instance: instance,
+	}
+}
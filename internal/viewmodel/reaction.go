@@ -0,0 +1,23 @@
+package viewmodel
+
+import "cleanArch_with_postgres/internal/entity"
+
+type ReactionRequest struct {
+	Type string `json:"type" validate:"omitempty,slug,max=32"` // boşsa "like"; geçerli tipler config'den
+}
+
+type ReactionSummaryVM struct {
+	Title       string           `json:"title"`
+	Reactions   map[string]int64 `json:"reactions"`
+	MyReactions []string         `json:"my_reactions"`
+}
+
+func ToReactionCounts(counts []entity.BlogReactionCount) map[string]int64 {
+	out := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		if c.Count > 0 {
+			out[c.Type] = c.Count
+		}
+	}
+	return out
+}
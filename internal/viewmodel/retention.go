@@ -0,0 +1,21 @@
+package viewmodel
+
+import "time"
+
+// PurgeReportVM, purge işinin bir turda sildiği (dry-run'da sileceği) kayıtlar
+type PurgeReportVM struct {
+	DryRun          bool              `json:"dry_run"`
+	GeneratedAt     time.Time         `json:"generated_at"`
+	Retention       map[string]string `json:"retention"` // tür -> süre, "disabled" ise silinmez
+	Users           int               `json:"users"`
+	Blogs           int               `json:"blogs"`
+	Comments        int               `json:"comments"`
+	Notifications   int               `json:"notifications"`
+	Reactions       int64             `json:"reactions"`
+	MediaLinks      int64             `json:"media_links"`
+	Bookmarks       int64             `json:"bookmarks"`
+	UserIDs         []uint            `json:"user_ids"`
+	BlogIDs         []uint            `json:"blog_ids"`
+	CommentIDs      []uint            `json:"comment_ids"`
+	NotificationIDs []uint            `json:"notification_ids"`
+}
@@ -0,0 +1,50 @@
+package viewmodel
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type SitemapURLVM struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// SitemapVM, ya tek bir urlset ya da (50k URL aşılınca) alt sitemap'leri listeleyen index'tir
+type SitemapVM struct {
+	IsIndex bool
+	URLs    []SitemapURLVM // IsIndex ise alt sitemap adresleri
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	NS      string       `xml:"xmlns,attr"`
+	URLs    []sitemapLoc `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	NS       string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+func (s *SitemapVM) XML() ([]byte, error) {
+	locs := make([]sitemapLoc, len(s.URLs))
+	for i, u := range s.URLs {
+		locs[i] = sitemapLoc{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			locs[i].LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+	}
+	if s.IsIndex {
+		return marshalXML(sitemapIndex{NS: sitemapNS, Sitemaps: locs})
+	}
+	return marshalXML(sitemapURLSet{NS: sitemapNS, URLs: locs})
+}
@@ -0,0 +1,6 @@
+package viewmodel
+
+type TrendingBlogVM struct {
+	BlogVM
+	Score float64 `json:"score"`
+}
@@ -10,6 +10,7 @@ type UserVM struct {
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
 	Role      string    `json:"role"`
+	Locale    string    `json:"locale"`
 	Followers []string  `json:"followers"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -17,10 +18,11 @@ type UserVM struct {
 }
 
 type RegisterRequest struct {
-	Username string `json:"username"` // ***** validate:"required,min=3,max=20,label=kullanıcı adı"
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Role     string `json:"role"`
+	Username string `json:"username" validate:"required,username"`
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"password" validate:"required,min=8,max=72,password"` // bcrypt 72 byte'tan sonrasını yok sayar
+	Role     string `json:"role" validate:"required,role"`
+	Locale   string `json:"locale" validate:"omitempty,locale"`
 }
 
 type RegisterResponse struct {
@@ -30,22 +32,31 @@ type RegisterResponse struct {
 }
 
 type LoginRequest struct {
-	Identifier string `json:"identifier"`
-	Password   string `json:"password"`
+	Identifier string `json:"identifier" validate:"required"`
+	Password   string `json:"password" validate:"required"`
 }
 
 type LoginResponse struct {
-	Token    string `json:"token"`
-	ID       uint   `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
+	Token             string `json:"token"`
+	ID                uint   `json:"id"`
+	Username          string `json:"username"`
+	Email             string `json:"email"`
+	Role              string `json:"role"`
+	Locale            string `json:"locale"`
+	MustResetPassword bool   `json:"must_reset_password"`
 }
 
+// UpdateRequest, boş bırakılan alanlar değişmez
 type UpdateRequest struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Username string `json:"username" validate:"omitempty,username"`
+	Email    string `json:"email" validate:"omitempty,email,max=254"`
+	Password string `json:"password" validate:"omitempty,min=8,max=72,password"`
+	Locale   string `json:"locale" validate:"omitempty,locale"`
+}
+
+// PasswordResetRequest, operatörün şifre sıfırlaması (CLI)
+type PasswordResetRequest struct {
+	Password string `json:"password" validate:"required,min=8,max=72,password"`
 }
 
 type UpdateResponse struct {
@@ -53,6 +64,7 @@ type UpdateResponse struct {
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
 	Role      string    `json:"role"`
+	Locale    string    `json:"locale"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
@@ -62,6 +74,7 @@ func ToUserVM(u *entity.User) *UserVM {
 		Username:  u.Username,
 		Email:     u.Email,
 		Role:      string(u.Role),
+		Locale:    u.Locale,
 		Followers: u.Followers,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
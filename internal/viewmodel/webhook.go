@@ -0,0 +1,105 @@
+package viewmodel
+
+import (
+	"cleanArch_with_postgres/internal/entity"
+	"strings"
+	"time"
+)
+
+type WebhookVM struct {
+	ID        uint      `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedBy string    `json:"created_by"`
+	Secret    string    `json:"secret,omitempty"` // sadece oluşturulurken bir kez döner
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookCreateVM struct {
+	URL    string   `json:"url" validate:"required,http_url"`
+	Events []string `json:"events" validate:"required,min=1,dive,required"`
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=256"` // boşsa server üretir
+	Active *bool    `json:"active"`
+}
+
+type WebhookUpdateVM struct {
+	URL    string   `json:"url" validate:"omitempty,http_url"`
+	Events []string `json:"events" validate:"omitempty,dive,required"`
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=256"` // boşsa değişmez
+	Active *bool    `json:"active"`
+}
+
+type WebhookDeliveryVM struct {
+	ID             uint       `json:"id"`
+	WebhookID      uint       `json:"webhook_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastStatusCode int        `json:"last_status_code"`
+	LastError      string     `json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	ReplayOf       *uint      `json:"replay_of"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func ToWebhookVM(w *entity.Webhook) *WebhookVM {
+	return &WebhookVM{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    SplitList(w.Events),
+		Active:    w.Active,
+		CreatedBy: w.CreatedBy,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+func ToWebhookVMs(ws []entity.Webhook) []WebhookVM {
+	out := make([]WebhookVM, len(ws))
+	for i := range ws {
+		out[i] = *ToWebhookVM(&ws[i])
+	}
+	return out
+}
+
+func ToWebhookDeliveryVM(d *entity.WebhookDelivery) *WebhookDeliveryVM {
+	return &WebhookDeliveryVM{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		ReplayOf:       d.ReplayOf,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func ToWebhookDeliveryVMs(ds []entity.WebhookDelivery) []WebhookDeliveryVM {
+	out := make([]WebhookDeliveryVM, len(ds))
+	for i := range ds {
+		out[i] = *ToWebhookDeliveryVM(&ds[i])
+	}
+	return out
+}
+
+// SplitList, virgülle ayrılmış alanları (tags, events) boşlukları temizleyerek böler
+func SplitList(s string) []string {
+	out := []string{}
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}